@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// loadContractABI parses ERC20_ABI and merges in the ABI fragment at
+// extraPath, if set, for pairs that expose extra getters. The fragment may
+// add methods and events but not redefine the built-in ones.
+func loadContractABI(extraPath string) (abi.ABI, error) {
+	contractABI, err := abi.JSON(strings.NewReader(ERC20_ABI))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+	if extraPath == "" {
+		return contractABI, nil
+	}
+
+	f, err := os.Open(extraPath)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to open extra ABI: %v", err)
+	}
+	defer f.Close()
+
+	extra, err := abi.JSON(f)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse extra ABI %s: %v", extraPath, err)
+	}
+
+	for name, method := range extra.Methods {
+		if _, ok := contractABI.Methods[name]; ok {
+			return abi.ABI{}, fmt.Errorf("extra ABI %s redefines method %s", extraPath, name)
+		}
+		contractABI.Methods[name] = method
+	}
+	for name, event := range extra.Events {
+		if _, ok := contractABI.Events[name]; ok {
+			return abi.ABI{}, fmt.Errorf("extra ABI %s redefines event %s", extraPath, name)
+		}
+		contractABI.Events[name] = event
+	}
+
+	return contractABI, nil
+}
+
+// callView calls a view method of contractABI (including any merged extra
+// ABI) on address and returns the unpacked outputs.
+func (d *LPBurnDetector) callView(ctx context.Context, address common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := d.contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.callContract(ctx, ethereum.CallMsg{
+		To:   &address,
+		Data: data,
+		Gas:  d.config.CallGasLimit,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.contractABI.Unpack(method, result)
+}
+
+// checkLPExtraViews makes sure every configured LP view is a known method
+// that takes no arguments.
+func checkLPExtraViews(contractABI abi.ABI, views []string) error {
+	for _, name := range views {
+		method, ok := contractABI.Methods[name]
+		if !ok {
+			return fmt.Errorf("LP_EXTRA_VIEWS method %s is not in the contract ABI", name)
+		}
+		if len(method.Inputs) > 0 {
+			return fmt.Errorf("LP_EXTRA_VIEWS method %s must take no arguments", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import "github.com/ethereum/go-ethereum/common"
+
+// AddressSet is a set of addresses compared by value, so checks are immune
+// to the case of the configured strings.
+type AddressSet map[common.Address]struct{}
+
+// NewAddressSet builds a set from configured address strings, rejecting
+// malformed or mis-checksummed entries. name is the config key, for errors.
+func NewAddressSet(name string, values []string) (AddressSet, error) {
+	set := make(AddressSet, len(values))
+	for _, value := range values {
+		if _, err := normalizeAddress(name, value); err != nil {
+			return nil, err
+		}
+		set[common.HexToAddress(value)] = struct{}{}
+	}
+	return set, nil
+}
+
+func (s AddressSet) Contains(addr common.Address) bool {
+	_, ok := s[addr]
+	return ok
+}
+
+func (s AddressSet) Len() int {
+	return len(s)
+}
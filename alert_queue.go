@@ -0,0 +1,77 @@
+package main
+
+import "sync"
+
+type Alert struct {
+	Text string
+	Mcap int64
+
+	// Thread groups related alerts, normally by LP address: with reply
+	// threading on, later alerts reply to the first one sent.
+	Thread string
+
+	// PhotoURL, if set, is sent as a photo with the text as its caption.
+	PhotoURL string
+
+	// OnSent, if set, is called by the Telegram notifier with the sent
+	// message and its final text, so it can be edited later.
+	OnSent func(msg TelegramMessage, text string)
+}
+
+// alertQueue buffers outbound alerts so they can be paced under Telegram's
+// rate limits. When full, the alert with the smallest market cap is dropped.
+type alertQueue struct {
+	mu      sync.Mutex
+	pending []Alert
+	size    int
+	ready   chan struct{}
+}
+
+func newAlertQueue(size int) *alertQueue {
+	return &alertQueue{
+		size:  size,
+		ready: make(chan struct{}, 1),
+	}
+}
+
+// push adds an alert to the queue. If the queue is over capacity the
+// lowest-priority alert (which may be the one just pushed) is evicted and
+// returned.
+func (q *alertQueue) push(alert Alert) *Alert {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pending = append(q.pending, alert)
+
+	var dropped *Alert
+	if len(q.pending) > q.size {
+		lowest := 0
+		for i, a := range q.pending {
+			if a.Mcap < q.pending[lowest].Mcap {
+				lowest = i
+			}
+		}
+		evicted := q.pending[lowest]
+		q.pending = append(q.pending[:lowest], q.pending[lowest+1:]...)
+		dropped = &evicted
+	}
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+
+	return dropped
+}
+
+func (q *alertQueue) pop() (Alert, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) == 0 {
+		return Alert{}, false
+	}
+	alert := q.pending[0]
+	q.pending = q.pending[1:]
+	return alert, true
+}
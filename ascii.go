@@ -0,0 +1,59 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"unicode"
+)
+
+// asciiReplacer maps the emoji used in logs and alerts to ASCII stand-ins.
+// The variation selector (U+FE0F) that follows some of them is dropped.
+var asciiReplacer = strings.NewReplacer(
+	"️", "",
+	"🚀", ">>",
+	"🔗", "[link]",
+	"📱", "[tg]",
+	"🔍", "[scan]",
+	"📡", "[listen]",
+	"📝", "[tx]",
+	"❌", "[x]",
+	"🔥", "[burn]",
+	"⚠", "[!]",
+	"🛑", "[stop]",
+	"🌐", "[http]",
+	"👀", "[watch]",
+	"💰", "[$]",
+	"🔵", "[*]",
+	"👤", "[holders]",
+	"🟩", "[ok]",
+	"🟥", "[!!]",
+	"🟨", "[?]",
+	"🧾", "[raw]",
+	"⎿", "L",
+)
+
+// toASCII replaces known emoji with ASCII equivalents and drops any other
+// pictographic symbols, leaving the rest of the text (including non-Latin
+// token names) untouched.
+func toASCII(s string) string {
+	s = asciiReplacer.Replace(s)
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII && unicode.Is(unicode.So, r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// asciiWriter rewrites everything written through it with toASCII. It is
+// installed as the log output in ASCII mode.
+type asciiWriter struct {
+	w io.Writer
+}
+
+func (a asciiWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(a.w, toASCII(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// backtestCase is one line of a backtest file: a tx hash optionally followed
+// by the expected classification ("burn" or "skip").
+type backtestCase struct {
+	TxHash   common.Hash
+	Expected string
+}
+
+func readBacktestFile(path string) ([]backtestCase, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backtest file: %v", err)
+	}
+	defer f.Close()
+
+	var cases []backtestCase
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields[0]) != 66 || !strings.HasPrefix(fields[0], "0x") {
+			return nil, fmt.Errorf("line %d: invalid tx hash %q", lineNo, fields[0])
+		}
+		c := backtestCase{TxHash: common.HexToHash(fields[0])}
+		if len(fields) > 1 {
+			c.Expected = strings.ToLower(fields[1])
+			if c.Expected != "burn" && c.Expected != "skip" {
+				return nil, fmt.Errorf("line %d: expected label must be burn or skip, got %q", lineNo, fields[1])
+			}
+		}
+		cases = append(cases, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read backtest file: %v", err)
+	}
+	return cases, nil
+}
+
+// classifyTx runs a mined tx through processLPBurn the way watchLogs would,
+// using its receipt logs, and returns "burn", "skip" or "error".
+func (d *LPBurnDetector) classifyTx(txHash common.Hash) (string, error) {
+	ctx, cancel := d.newEventContext()
+	defer cancel()
+
+	receipt, err := d.client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return "error", fmt.Errorf("failed to get receipt: %v", err)
+	}
+
+	var logs []types.Log
+	for _, vLog := range receipt.Logs {
+		if d.fromAddrs.Len() > 0 && (len(vLog.Topics) < 2 || !d.fromAddrs.Contains(topicAddress(vLog.Topics[1]))) {
+			continue
+		}
+		logs = append(logs, *vLog)
+	}
+	if len(receipt.Logs) > 0 && len(logs) == 0 {
+		return "skip", skipf("no transfers from watchlisted addresses")
+	}
+
+	err = d.processLPBurn(ctx, txHash, receipt.BlockNumber, logs)
+	switch {
+	case err == nil:
+		return "burn", nil
+	case isSkip(err):
+		return "skip", err
+	default:
+		return "error", err
+	}
+}
+
+// runBacktest classifies every tx in path and writes one line per tx plus a
+// summary to out. Alerts are never sent; the detector must be in dry-run.
+func (d *LPBurnDetector) runBacktest(path string, out io.Writer) error {
+	cases, err := readBacktestFile(path)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int)
+	passed, failed := 0, 0
+	for _, c := range cases {
+		class, err := d.classifyTx(c.TxHash)
+		counts[class]++
+
+		verdict := ""
+		if c.Expected != "" {
+			if class == c.Expected {
+				verdict = "PASS "
+				passed++
+			} else {
+				verdict = "FAIL "
+				failed++
+			}
+		}
+
+		line := fmt.Sprintf("%s%s %s", verdict, c.TxHash.Hex(), class)
+		if err != nil {
+			line += ": " + err.Error()
+		}
+		fmt.Fprintln(out, line)
+	}
+
+	fmt.Fprintf(out, "\n%d tx(s): %d burn, %d skip, %d error\n", len(cases), counts["burn"], counts["skip"], counts["error"])
+	if passed+failed > 0 {
+		fmt.Fprintf(out, "%d labeled: %d passed, %d failed\n", passed+failed, passed, failed)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerHalfOpen
+	breakerOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerClosed:
+		return "closed"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "open"
+	}
+}
+
+var ErrBreakerOpen = errors.New("circuit breaker open")
+
+// CircuitBreaker stops calls to an external provider after threshold
+// consecutive failures. Once cooldown has passed a single probe call is let
+// through (half-open); its outcome closes or re-opens the breaker.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	name      string
+	threshold int
+	cooldown  time.Duration
+	state     breakerState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	clock     Clock
+}
+
+func NewCircuitBreaker(name string, threshold int, cooldown time.Duration, clock Clock) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+		clock:     clock,
+	}
+}
+
+// Allow reports whether a call may proceed. Every allowed call must be
+// followed by Record.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.clock.Now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		log.Printf("🔌 %s circuit breaker half-open, probing", b.name)
+		fallthrough
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Record feeds a call's outcome back into the breaker. Only transient
+// failures count against the provider: a 4xx means it answered, and a call
+// abandoned because the caller's ctx ended says nothing about its health.
+func (b *CircuitBreaker) Record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err == nil || !retryable(err) {
+		if b.state != breakerClosed {
+			log.Printf("🔌 %s circuit breaker closed", b.name)
+		}
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		if b.state != breakerOpen {
+			log.Printf("🔌 %s circuit breaker open after %d consecutive failure(s)", b.name, b.failures)
+		}
+		b.state = breakerOpen
+		b.openedAt = b.clock.Now()
+	}
+}
+
+func (b *CircuitBreaker) State() breakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// call runs op through the breaker, failing fast while it is open.
+func (b *CircuitBreaker) call(ctx context.Context, op func() error) error {
+	if !b.Allow() {
+		return fmt.Errorf("%s: %w", b.name, ErrBreakerOpen)
+	}
+	err := op()
+	b.Record(ctx, err)
+	return err
+}
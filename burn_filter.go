@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// lpSupplyTTL is how long an LP's total supply is reused by the
+	// MinBurnPercent pre-filter; it only needs to be roughly right.
+	lpSupplyTTL = 10 * time.Minute
+
+	lpSupplyTimeout = 5 * time.Second
+)
+
+// lpSupplyCache remembers LP total supplies for the pre-filter so a busy
+// LP costs one totalSupply() call per lpSupplyTTL.
+type lpSupplyCache struct {
+	mu       sync.Mutex
+	supplies map[common.Address]lpSupplyEntry
+}
+
+type lpSupplyEntry struct {
+	supply    *big.Int
+	fetchedAt time.Time
+}
+
+func newLPSupplyCache() *lpSupplyCache {
+	return &lpSupplyCache{supplies: make(map[common.Address]lpSupplyEntry)}
+}
+
+func (c *lpSupplyCache) get(lp common.Address, now time.Time) (*big.Int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.supplies[lp]
+	if !ok || now.Sub(entry.fetchedAt) >= lpSupplyTTL {
+		return nil, false
+	}
+	return entry.supply, true
+}
+
+func (c *lpSupplyCache) set(lp common.Address, supply *big.Int, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.supplies[lp] = lpSupplyEntry{supply: supply, fetchedAt: now}
+}
+
+// isDustBurn reports whether a dead-address Transfer moves at most
+// MinBurnRaw; it needs no RPC, so it runs as logs arrive.
+func (d *LPBurnDetector) isDustBurn(vLog types.Log) bool {
+	value, ok := burnValue(vLog)
+	return ok && value.Cmp(d.config.MinBurnRaw) <= 0
+}
+
+// belowMinBurn reports whether a dead-address Transfer burns less than
+// MinBurnPercent of its LP's supply. It may call totalSupply(), so it runs
+// on the workers; when the supply can't be read the transfer is let through.
+func (d *LPBurnDetector) belowMinBurn(ctx context.Context, vLog types.Log) (bool, float64) {
+	value, ok := burnValue(vLog)
+	if !ok {
+		return false, 0
+	}
+
+	now := d.clock.Now()
+	supply, ok := d.lpSupplies.get(vLog.Address, now)
+	if !ok {
+		ctx, cancel := context.WithTimeout(ctx, lpSupplyTimeout)
+		defer cancel()
+		var err error
+		if supply, err = d.getTokenSupply(ctx, vLog.Address, nil); err != nil || supply.Sign() == 0 {
+			return false, 0
+		}
+		d.lpSupplies.set(vLog.Address, supply, now)
+	}
+
+	percent, _ := newFloat().Quo(floatFromInt(value), floatFromInt(supply)).Float64()
+	percent *= 100
+	return percent < d.config.MinBurnPercent, percent
+}
+
+func burnValue(vLog types.Log) (*big.Int, bool) {
+	if len(vLog.Topics) != 3 || vLog.Topics[0] != transferEventTopic || len(vLog.Data) != 32 {
+		return nil, false
+	}
+	return new(big.Int).SetBytes(vLog.Data), true
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/hex"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Burn methods, by how the tx that burned the LP was called.
+const (
+	BurnMethodDirect   = "direct transfer"
+	BurnMethodRouter   = "router"
+	BurnMethodLocker   = "locker"
+	BurnMethodFunction = "burn function"
+	BurnMethodContract = "contract call"
+)
+
+func selectorsOf(signatures ...string) map[string]bool {
+	selectors := make(map[string]bool, len(signatures))
+	for _, sig := range signatures {
+		selectors[hex.EncodeToString(crypto.Keccak256([]byte(sig))[:4])] = true
+	}
+	return selectors
+}
+
+var (
+	// routerSelectors are the Uniswap V2 router's liquidity calls and the
+	// multicall/execute entry points of the V3 and universal routers.
+	routerSelectors = selectorsOf(
+		"addLiquidity(address,address,uint256,uint256,uint256,uint256,address,uint256)",
+		"addLiquidityETH(address,uint256,uint256,uint256,address,uint256)",
+		"removeLiquidity(address,address,uint256,uint256,uint256,address,uint256)",
+		"removeLiquidityETH(address,uint256,uint256,uint256,address,uint256)",
+		"removeLiquidityWithPermit(address,address,uint256,uint256,uint256,address,uint256,bool,uint8,bytes32,bytes32)",
+		"removeLiquidityETHWithPermit(address,uint256,uint256,uint256,address,uint256,bool,uint8,bytes32,bytes32)",
+		"removeLiquidityETHSupportingFeeOnTransferTokens(address,uint256,uint256,uint256,address,uint256)",
+		"removeLiquidityETHWithPermitSupportingFeeOnTransferTokens(address,uint256,uint256,uint256,address,uint256,bool,uint8,bytes32,bytes32)",
+		"multicall(bytes[])",
+		"multicall(uint256,bytes[])",
+		"execute(bytes,bytes[])",
+		"execute(bytes,bytes[],uint256)",
+	)
+
+	// lockerSelectors are the withdraw calls of the common LP lockers
+	// (UNCX, Team Finance, PinkLock).
+	lockerSelectors = selectorsOf(
+		"withdraw(address,uint256,uint256,uint256)",
+		"withdrawTokens(uint256)",
+		"unlock(uint256)",
+	)
+
+	burnSelectors = selectorsOf(
+		"burn(uint256)",
+		"burn(address,uint256)",
+		"burnFrom(address,uint256)",
+	)
+
+	directSelectors = selectorsOf(
+		"transfer(address,uint256)",
+		"transferFrom(address,address,uint256)",
+	)
+)
+
+// burnMethod classifies how tx burned lpAddress's tokens from the call it
+// made: a transfer on the LP token itself, a router or locker call, a
+// burn function, or any other contract call.
+func (d *LPBurnDetector) burnMethod(tx *types.Transaction, lpAddress common.Address) string {
+	if tx.To() == nil || len(tx.Data()) < 4 {
+		return BurnMethodContract
+	}
+	selector := hex.EncodeToString(tx.Data()[:4])
+
+	switch {
+	case *tx.To() == lpAddress && directSelectors[selector]:
+		return BurnMethodDirect
+	case *tx.To() == common.HexToAddress(d.config.RouterAddr) || routerSelectors[selector]:
+		return BurnMethodRouter
+	case lockerSelectors[selector]:
+		return BurnMethodLocker
+	case burnSelectors[selector]:
+		return BurnMethodFunction
+	default:
+		return BurnMethodContract
+	}
+}
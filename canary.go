@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// canaryState tracks the canary check; failing is sticky until a run passes,
+// so a broken upstream sends one admin alert rather than one per interval.
+type canaryState struct {
+	failing  atomic.Bool
+	failures atomic.Int64
+	runs     atomic.Int64
+}
+
+// runCanary checks the external APIs against the configured stable token and
+// pool every CanaryInterval.
+func (d *LPBurnDetector) runCanary(ctx context.Context) {
+	for {
+		err := d.checkCanary()
+		d.canary.runs.Add(1)
+		if err != nil {
+			d.canary.failures.Add(1)
+			log.Printf("🐤 Canary check failed: %v", err)
+			if !d.canary.failing.Swap(true) {
+				d.alertAdmin(ctx, fmt.Sprintf("⚠️ <b>Canary check failed</b>\nToken %s, pool %s\n%s",
+					d.config.CanaryToken, d.config.CanaryPool, html.EscapeString(err.Error())))
+			}
+		} else if d.canary.failing.Swap(false) {
+			log.Println("🐤 Canary check passing again")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.clock.After(d.config.CanaryInterval):
+		}
+	}
+}
+
+// checkCanary fetches details and a price for the canary token and pool and
+// fails on errors or empty results, which is how an upstream shape change
+// shows up once decoded leniently.
+func (d *LPBurnDetector) checkCanary() error {
+	ctx, cancel := d.newEventContext()
+	defer cancel()
+
+	var problems []string
+
+	// Each provider is checked on its own: getDetails falls back between
+	// them, which would hide one of them breaking
+	providers := []DetailsProvider{d.metadataProvider}
+	if d.securityProvider != nil {
+		providers = append(providers, d.securityProvider)
+	}
+	for _, provider := range providers {
+		details, err := provider.TokenDetails(ctx, d.config.CanaryToken)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s details: %v", provider.Name(), err))
+		} else if details.TokenName == "" || details.TokenSymbol == "" {
+			problems = append(problems, fmt.Sprintf("%s details: empty name or symbol", provider.Name()))
+		}
+	}
+
+	pool := common.HexToAddress(d.config.CanaryPool)
+	token := common.HexToAddress(d.config.CanaryToken)
+	var priceData *PriceData
+	var err error
+	if d.config.GeckoSupported {
+		baseToken := 0
+		if token0, err := d.getToken0(ctx, pool); err == nil {
+			baseToken = geckoBaseToken(token0, token)
+		}
+		priceData, err = d.getPriceData(ctx, pool.Hex(), baseToken)
+	} else {
+		priceData, err = d.getDexScreenerPriceData(ctx, pool, token)
+	}
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("price: %v", err))
+	} else if price, _ := strconv.ParseFloat(priceData.Price, 64); price <= 0 {
+		problems = append(problems, "price: empty price")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
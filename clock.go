@@ -0,0 +1,22 @@
+package main
+
+import "time"
+
+// Clock is the detector's source of time. Cooldowns, caches, windows and
+// periodic loops go through it so tests can substitute a fake clock and
+// advance time deterministically.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the wall clock.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,1249 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Config holds the runtime-tunable settings. Every field can be overridden
+// through the environment variable named in LoadConfig.
+type Config struct {
+	DeadAddr string
+	WethAddr string
+
+	// FromAddrs restricts the log subscription to burns sent by these
+	// addresses (e.g. a deployer watchlist). Empty means any sender.
+	FromAddrs []string
+
+	// PoolProjectTokens forces which side of a pool is the project token,
+	// keyed by pool address. ProjectTokens does the same for any pool one of
+	// the listed tokens appears in. Both take precedence over the WETH check.
+	PoolProjectTokens map[string]string
+	ProjectTokens     []string
+
+	// ProjectTokens' metadata and decimals are prefetched at startup,
+	// PrefetchConcurrency at a time, and refreshed every
+	// MetadataRefreshInterval.
+	PrefetchConcurrency     int
+	MetadataRefreshInterval time.Duration
+
+	// ASCIIMode replaces emoji in log output with ASCII equivalents for
+	// terminals that can't render them; ASCIIAlerts does the same for alerts.
+	ASCIIMode   bool
+	ASCIIAlerts bool
+
+	TelegramMinInterval time.Duration
+	TelegramQueueSize   int
+	TelegramTimeout     time.Duration
+	ConsoleAlerts       bool
+
+	// CanaryToken and CanaryPool, if set, are a known stable token and its
+	// pool that the details and price APIs are checked against every
+	// CanaryInterval; a failure alerts the admin chat.
+	CanaryToken    string
+	CanaryPool     string
+	CanaryInterval time.Duration
+
+	// SlackWebhookURL, if set, also posts every alert to that Slack
+	// incoming webhook; SlackChannel overrides the webhook's channel.
+	SlackWebhookURL string
+	SlackChannel    string
+	SlackTimeout    time.Duration
+
+	// WebhookURL, if set, also posts every alert as JSON to that URL. With
+	// WebhookSecret each request is HMAC-SHA256 signed; see WebhookNotifier.
+	WebhookURL     string
+	WebhookSecret  string
+	WebhookTimeout time.Duration
+
+	// AlertDigestInterval, if set, switches to digest mode: burns are
+	// collected and sent as one summary message per interval instead of
+	// one alert each.
+	AlertDigestInterval time.Duration
+
+	// ReplyThreadTTL is how long the first Telegram alert for an LP stays
+	// the one later alerts for that LP reply to; 0 disables threading.
+	ReplyThreadTTL time.Duration
+
+	// SkipLogEvery logs one in every N expected skips ("not an LP burn");
+	// 0 disables them. Counts are summarized every SkipSummaryInterval.
+	SkipLogEvery        int
+	SkipSummaryInterval time.Duration
+
+	// LatePriceWindow is how long a burn alerted with no market cap keeps
+	// being re-priced (every LatePriceInterval); once a price shows up the
+	// Telegram message is edited. 0 disables it.
+	LatePriceWindow   time.Duration
+	LatePriceInterval time.Duration
+
+	// RecentAddLookback is how many blocks before a burn are searched for
+	// liquidity adds to the pair; a burn covered by them while older LP
+	// remains is flagged. 0 disables the check.
+	RecentAddLookback uint64
+
+	// McapSanityRatio is how far (as a factor either way) a GeckoTerminal
+	// market cap may be from the pool's on-chain estimate before Gecko is
+	// refetched, up to McapSanityRetries times, and then overruled by the
+	// on-chain figure; 0 disables the check.
+	McapSanityRatio   float64
+	McapSanityRetries int
+
+	// MinLiquidityUSD skips pools with less liquidity than this (0
+	// disables it). When no price source knows the liquidity the burn is
+	// still alerted unless AlertOnUnknownLiquidity is off.
+	MinLiquidityUSD         float64
+	AlertOnUnknownLiquidity bool
+
+	// MaxSafeTax is the buy/sell tax fraction above which an alert gets a
+	// HIGH TAX warning (0 disables it); HideSnipeOnHighTax also drops the
+	// snipe-bot links from such alerts.
+	MaxSafeTax         float64
+	HideSnipeOnHighTax bool
+
+	// AlertIDs appends each event's ID (also in its log lines and stored
+	// record) to the alert.
+	AlertIDs bool
+
+	// AlertLogos sends an alert as the token's logo (from DexScreener) with
+	// the alert as its caption, when the token has one.
+	AlertLogos bool
+
+	// FooterText (linked to FooterURL, if set) closes every alert so
+	// reposted alerts carry their source. FooterEnabled false drops it.
+	FooterEnabled bool
+	FooterText    string
+	FooterURL     string
+
+	// Language picks the alert labels: English is built in, other
+	// languages are read from <LocaleDir>/<Language>.json.
+	Language  string
+	LocaleDir string
+
+	// ArchiveNodeURL, if set, serves contract reads pinned to a block
+	// (supply and reserves at the burn), which a full node may have
+	// pruned. Subscriptions and latest-state reads stay on the primary node.
+	ArchiveNodeURL string
+
+	// InstanceName tags alerts, log lines and metrics so several detectors
+	// can share a Telegram channel and a Prometheus, e.g. "ETH-Mainnet".
+	InstanceName string
+
+	// DryRun runs the full detection pipeline but neither sends alerts nor
+	// records burns. BacktestFile (tx hashes, one per line, optionally
+	// labeled "burn" or "skip") classifies those txs in dry-run and exits.
+	DryRun       bool
+	BacktestFile string
+
+	// ReplayFile, if set, replaces the live subscription with recorded logs
+	// (one JSON log per line, as returned by eth_getLogs) fed through the
+	// same processing path, to reproduce reported burns. RPC and API calls
+	// stay live, and alerts are sent unless DryRun is on.
+	ReplayFile string
+
+	// HeartbeatInterval is how often a throughput summary is logged; 0
+	// disables it.
+	HeartbeatInterval time.Duration
+
+	// NodeDialAttempts bounds the connection attempts to the node at
+	// startup (0 retries until shutdown), backing off from NodeDialDelay.
+	NodeDialAttempts int
+	NodeDialDelay    time.Duration
+
+	// SubscriptionMode is "ws" (WebSocket subscriptions), "poll"
+	// (eth_getLogs every PollInterval, for HTTP-only nodes) or "auto":
+	// ws for a WebSocket node URL, falling back to poll if subscribing
+	// fails.
+	SubscriptionMode string
+	PollInterval     time.Duration
+
+	// ReconnectDelay is the pause before resubscribing after the node
+	// subscription fails. ReconnectAlertThreshold failures within
+	// ReconnectAlertWindow send an admin alert to AdminChatID (0 disables
+	// it); StableUptime connected without a failure resets the count.
+	ReconnectDelay          time.Duration
+	ReconnectAlertThreshold int
+	ReconnectAlertWindow    time.Duration
+	StableUptime            time.Duration
+	AdminChatID             string
+
+	// TxLookupAttempts and TxLookupDelay control retrying a tx lookup that
+	// comes back not-found or pending right after its log was seen.
+	TxLookupAttempts int
+	TxLookupDelay    time.Duration
+
+	// BurnFlushWindow is how long logs are collected per tx before it is
+	// processed, so multiple dead-address transfers in one tx make one alert.
+	BurnFlushWindow time.Duration
+
+	// WorkerCount is how many txs are processed at once. WorkQueueSize
+	// bounds the batches and liquidity adds waiting for a worker; past it
+	// they're dropped rather than stalling the subscription.
+	WorkerCount   int
+	WorkQueueSize int
+
+	// BurnGraceWindow holds a burn of less than FullBurnPercent of its LP
+	// for this long, adding further burns of the same LP to it, so a lock
+	// finished in follow-up txs alerts once; 0 alerts immediately.
+	BurnGraceWindow time.Duration
+	FullBurnPercent float64
+
+	// ProcessTimeout bounds the whole handling of one burn. Enrichment that
+	// hasn't finished by then falls back to defaults so a best-effort alert
+	// is still sent.
+	ProcessTimeout time.Duration
+
+	// EnrichConcurrency bounds how many of a burn's enrichment calls
+	// (details, price, supply, decimals, balances) run at once; each gets
+	// at most EnrichTimeout.
+	EnrichConcurrency int
+	EnrichTimeout     time.Duration
+
+	// RetryBudget and RetryBudgetTime cap the retries (count and total
+	// backoff) shared by everything one burn does; once spent, failures
+	// aren't retried and the alert goes out best-effort. 0 is unlimited.
+	RetryBudget     int
+	RetryBudgetTime time.Duration
+
+	// HTTPMaxAttempts bounds the tries for each GoPlus/GeckoTerminal request;
+	// transient failures back off exponentially from HTTPRetryBaseDelay.
+	HTTPMaxAttempts    int
+	HTTPRetryBaseDelay time.Duration
+
+	// FirstBurnOnly alerts only on a token's first recorded burn, ignoring
+	// later liquidity-management burns.
+	FirstBurnOnly bool
+
+	// LPNamePatterns are the substrings (case-sensitive) an LP token's
+	// name or symbol must contain for its burn to be analyzed.
+	LPNamePatterns []string
+
+	// SupplyWatchPairs are polled for totalSupply() every block, alerting
+	// when it drops by SupplyDropFraction or more. This catches burns that
+	// emit no Transfer event, at the cost of an RPC call per pair per block.
+	SupplyWatchPairs   []string
+	SupplyDropFraction float64
+
+	// CirculatingLPPercent measures a burn against the LP supply that was
+	// circulating before it (total minus what the dead and zero addresses
+	// already held) instead of the total supply.
+	CirculatingLPPercent bool
+
+	// BreakerThreshold consecutive transient failures open a provider's
+	// circuit breaker; it probes again after BreakerCooldown.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+
+	BurnStorePath string
+	HTTPAddr      string
+
+	// CursorPath stores the last scanned block. On startup the detector
+	// catches up from it if it is at most CatchUpWindow old, and otherwise
+	// goes straight to live; 0 disables catch-up.
+	CursorPath    string
+	CatchUpWindow time.Duration
+
+	// ProcessedTxsPath records the txs already alerted on, so catch-up and
+	// reconnects don't alert twice. Entries expire after ProcessedTxsTTL (0
+	// keeps them until evicted) and at most ProcessedTxsSize are kept.
+	ProcessedTxsPath string
+	ProcessedTxsSize int
+	ProcessedTxsTTL  time.Duration
+
+	// A new head older than ResyncStaleAge, or the head jumping back, means
+	// the node is replaying blocks: alerts are then only logged until heads
+	// have looked normal for ResyncSettle. 0 disables the check.
+	ResyncStaleAge time.Duration
+	ResyncSettle   time.Duration
+
+	// HolderCountSource is "goplus", "explorer" (Ethplorer) or "both",
+	// which prefers GoPlus and cross-checks it against the explorer.
+	HolderCountSource string
+	EthplorerAPIKey   string
+
+	// VerboseAlerts adds the raw burned amount in wei, the gas price and the
+	// sender to each alert.
+	VerboseAlerts bool
+
+	// ExtraABIFile is a JSON ABI fragment merged into the ERC20 ABI for
+	// pairs with non-standard getters. LPExtraViews names no-argument
+	// methods read from the LP and listed in the verbose section.
+	ExtraABIFile string
+	LPExtraViews []string
+
+	// TaxSimulation measures buy/sell tax on-chain when GoPlus has no data.
+	// It needs a node that supports eth_simulateV1 state overrides.
+	TaxSimulation bool
+	RouterAddr    string
+	TaxSimBuyWei  *big.Int
+
+	// DexScreenerChain, DexToolsChain and DexSpyChain are the chain's slug
+	// on each site, for DexScreener lookups and the alert's chart links.
+	DexScreenerChain string
+	DexToolsChain    string
+	DexSpyChain      string
+
+	// WatchEvents selects the events that trigger alerts: "dead_transfer"
+	// (LP tokens sent to DeadAddr), "v2_burn" and "v3_burn" (liquidity
+	// removed from a V2 pair / V3 pool). FromAddrs only filters
+	// dead_transfer.
+	WatchEvents []string
+
+	// MinRemovalPercent skips v2_burn removals of less than this share of
+	// the pool's LP supply, so only rug-sized removals alert; 0 alerts on
+	// every removal.
+	MinRemovalPercent float64
+
+	// DropZeroValue discards zero-value dead-address transfers as soon as
+	// they arrive, before any RPC calls are made for them.
+	DropZeroValue bool
+
+	// MinBurnPercent drops dead-address transfers of less than this share of
+	// the LP supply before processing; 0 disables it. Transfers of at most
+	// MinBurnRaw (raw units) are dropped as dust as soon as they arrive.
+	MinBurnPercent float64
+	MinBurnRaw     *big.Int
+
+	// WatchLiquidityAdds also alerts on liquidity being added to V2 pairs.
+	WatchLiquidityAdds bool
+
+	// CallGasLimit caps the gas of every contract read so a token with a
+	// gas-burning view function fails fast; 0 leaves it to the node.
+	CallGasLimit uint64
+
+	// DEXProbe reads the pair's factory() to name its DEX in the alert.
+	DEXProbe bool
+
+	// TopHolders is how many of the largest token holders an alert lists,
+	// up to maxTopHolders; 0 drops the line. ExplorerURL is the block
+	// explorer their addresses link to.
+	TopHolders  int
+	ExplorerURL string
+
+	// ENSLookup shows the primary ENS name of holder and sender addresses in
+	// alerts. Each new address costs a few RPC calls; results are cached.
+	ENSLookup   bool
+	ENSRegistry string
+
+	// QuotePriceUSD (static) or QuotePriceOracle (a Chainlink-style
+	// aggregator) price the wrapped native token for the on-chain market cap
+	// fallback, for chains the price APIs don't cover.
+	QuotePriceUSD    float64
+	QuotePriceOracle string
+
+	GeckoNetwork  string
+	StableAddrs   []string
+	QuotePriceTTL time.Duration
+
+	// RiskWeights is each risk signal's contribution to a burn's risk score
+	// (see risk.go); a score of RiskDangerScore or more is DANGER and of
+	// RiskCautionScore or more CAUTION. RiskConcentrationPercent and
+	// RiskMinBurnPercent are the holder concentration and burned share
+	// thresholds of their signals.
+	RiskWeights              map[string]int
+	RiskCautionScore         int
+	RiskDangerScore          int
+	RiskConcentrationPercent float64
+	RiskMinBurnPercent       float64
+
+	// FloatPrecision is the precision, in bits, of the big.Float math behind
+	// supplies, prices, market caps and percentages.
+	FloatPrecision int
+
+	// QuoteDecimals gives the decimals of known quote tokens, keyed by
+	// address, for converting pool reserves to USD. Tokens not listed are
+	// read on-chain.
+	QuoteDecimals map[string]uint8
+
+	// GoPlusSupported and GeckoSupported say whether GoPlus and
+	// GeckoTerminal cover this chain. When off the provider is never called
+	// and alerts rely on on-chain metadata and prices instead.
+	GoPlusSupported bool
+	GeckoSupported  bool
+}
+
+// maxTopHolders caps TopHolders; GoPlus returns at most 10 holders.
+const maxTopHolders = 10
+
+func DefaultConfig() Config {
+	return Config{
+		DeadAddr: DEAD_ADDR,
+		WethAddr: WETH_ADDR,
+
+		PrefetchConcurrency:     4,
+		MetadataRefreshInterval: time.Hour,
+
+		// Telegram allows ~30 msg/s globally but only ~20 msg/min into a
+		// single group, so default to one message every 3 seconds.
+		TelegramMinInterval: 3 * time.Second,
+		TelegramQueueSize:   50,
+		TelegramTimeout:     10 * time.Second,
+		SlackTimeout:        10 * time.Second,
+		WebhookTimeout:      10 * time.Second,
+
+		CanaryInterval: 15 * time.Minute,
+
+		SkipLogEvery:        10,
+		SkipSummaryInterval: 5 * time.Minute,
+
+		LatePriceWindow:   10 * time.Minute,
+		LatePriceInterval: time.Minute,
+
+		AlertOnUnknownLiquidity: true,
+		RecentAddLookback:       300, // ~1 hour
+
+		Language:  "en",
+		LocaleDir: "locales",
+
+		MaxSafeTax: 0.3,
+
+		McapSanityRatio:   10,
+		McapSanityRetries: 1,
+
+		SupplyDropFraction: 0.05,
+
+		HeartbeatInterval: 10 * time.Minute,
+
+		NodeDialAttempts: 10,
+		NodeDialDelay:    2 * time.Second,
+
+		SubscriptionMode: SubscriptionAuto,
+		PollInterval:     12 * time.Second,
+
+		ReconnectDelay:          5 * time.Second,
+		ReconnectAlertThreshold: 5,
+		ReconnectAlertWindow:    15 * time.Minute,
+		StableUptime:            10 * time.Minute,
+
+		TxLookupAttempts: 5,
+		TxLookupDelay:    500 * time.Millisecond,
+
+		BurnFlushWindow: 2 * time.Second,
+		WorkerCount:     1,
+		WorkQueueSize:   100,
+		FullBurnPercent: 99.5,
+		ProcessTimeout:  60 * time.Second,
+
+		EnrichConcurrency: 6,
+		EnrichTimeout:     30 * time.Second,
+		RetryBudget:       10,
+		RetryBudgetTime:   15 * time.Second,
+
+		HTTPMaxAttempts:    3,
+		HTTPRetryBaseDelay: 500 * time.Millisecond,
+
+		BreakerThreshold: 5,
+		BreakerCooldown:  60 * time.Second,
+
+		BurnStorePath: "burns.jsonl",
+		HTTPAddr:      ":8080",
+		CursorPath:    "cursor.txt",
+		CatchUpWindow: 6 * time.Hour,
+
+		ProcessedTxsPath: "processed.txt",
+		ProcessedTxsSize: 10000,
+		ProcessedTxsTTL:  7 * 24 * time.Hour,
+
+		ResyncStaleAge: 2 * time.Minute,
+		ResyncSettle:   time.Minute,
+
+		HolderCountSource: HolderSourceGoPlus,
+		EthplorerAPIKey:   "freekey",
+
+		LPNamePatterns: []string{"Uniswap", "UNI-V2", "SLP", "Cake-LP"},
+
+		RouterAddr:   "0x7a250d5630b4cf539739df2c5dacb4c659f2488d", // Uniswap V2 router
+		TaxSimBuyWei: big.NewInt(50000000000000000),                // 0.05 ETH
+
+		CallGasLimit:  2000000,
+		WatchEvents:   []string{EventDeadTransfer},
+		DropZeroValue: true,
+		MinBurnRaw:    big.NewInt(1000),
+
+		DexScreenerChain: "ethereum",
+		DexToolsChain:    "ether",
+		DexSpyChain:      "eth",
+		ENSRegistry:      "0x00000000000c2e074ec69a0dfb2997ba6c7d2e1e",
+
+		FooterEnabled: true,
+		FooterText:    "🔥 LP Burn Detector",
+
+		TopHolders:  2,
+		ExplorerURL: "https://etherscan.io",
+
+		GeckoNetwork: "eth",
+		StableAddrs: []string{
+			"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", // USDC
+			"0xdac17f958d2ee523a2206206994597c13d831ec7", // USDT
+			"0x6b175474e89094c44da98b954eedeac495271d0f", // DAI
+		},
+		QuotePriceTTL: 60 * time.Second,
+		RiskWeights: map[string]int{
+			riskHoneypot:      100,
+			riskTax:           30,
+			riskOwner:         15,
+			riskSource:        20,
+			riskConcentration: 20,
+			riskBurn:          15,
+		},
+		RiskCautionScore:         20,
+		RiskDangerScore:          50,
+		RiskConcentrationPercent: 50,
+		RiskMinBurnPercent:       90,
+		FloatPrecision:           128,
+		QuoteDecimals: map[string]uint8{
+			"0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2": 18, // WETH
+			"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48": 6,  // USDC
+			"0xdac17f958d2ee523a2206206994597c13d831ec7": 6,  // USDT
+			"0x6b175474e89094c44da98b954eedeac495271d0f": 18, // DAI
+		},
+
+		GoPlusSupported: true,
+		GeckoSupported:  true,
+	}
+}
+
+func LoadConfig() (Config, error) {
+	cfg := DefaultConfig()
+
+	var err error
+	cfg.DeadAddr = envString("DEAD_ADDR", cfg.DeadAddr)
+	cfg.WethAddr = envString("WETH_ADDR", cfg.WethAddr)
+	cfg.FromAddrs = envList("FROM_ADDRS", cfg.FromAddrs)
+	if cfg.PoolProjectTokens, err = envMap("POOL_PROJECT_TOKENS", cfg.PoolProjectTokens); err != nil {
+		return cfg, err
+	}
+	cfg.ProjectTokens = envList("PROJECT_TOKENS", cfg.ProjectTokens)
+	if cfg.PrefetchConcurrency, err = envInt("PREFETCH_CONCURRENCY", cfg.PrefetchConcurrency); err != nil {
+		return cfg, err
+	}
+	if cfg.MetadataRefreshInterval, err = envDuration("METADATA_REFRESH_INTERVAL", cfg.MetadataRefreshInterval); err != nil {
+		return cfg, err
+	}
+	if cfg.ASCIIMode, err = envBool("ASCII_MODE", cfg.ASCIIMode); err != nil {
+		return cfg, err
+	}
+	if cfg.ASCIIAlerts, err = envBool("ASCII_ALERTS", cfg.ASCIIAlerts); err != nil {
+		return cfg, err
+	}
+	if cfg.TelegramMinInterval, err = envDuration("TELEGRAM_MIN_INTERVAL", cfg.TelegramMinInterval); err != nil {
+		return cfg, err
+	}
+	if cfg.TelegramQueueSize, err = envInt("TELEGRAM_QUEUE_SIZE", cfg.TelegramQueueSize); err != nil {
+		return cfg, err
+	}
+	if cfg.TelegramTimeout, err = envDuration("TELEGRAM_TIMEOUT", cfg.TelegramTimeout); err != nil {
+		return cfg, err
+	}
+	if cfg.ConsoleAlerts, err = envBool("CONSOLE_ALERTS", cfg.ConsoleAlerts); err != nil {
+		return cfg, err
+	}
+	cfg.CanaryToken = envString("CANARY_TOKEN", cfg.CanaryToken)
+	cfg.CanaryPool = envString("CANARY_POOL", cfg.CanaryPool)
+	if cfg.CanaryInterval, err = envDuration("CANARY_INTERVAL", cfg.CanaryInterval); err != nil {
+		return cfg, err
+	}
+	cfg.SlackWebhookURL = envString("SLACK_WEBHOOK_URL", cfg.SlackWebhookURL)
+	cfg.SlackChannel = envString("SLACK_CHANNEL", cfg.SlackChannel)
+	if cfg.SlackTimeout, err = envDuration("SLACK_TIMEOUT", cfg.SlackTimeout); err != nil {
+		return cfg, err
+	}
+	cfg.WebhookURL = envString("WEBHOOK_URL", cfg.WebhookURL)
+	cfg.WebhookSecret = envString("WEBHOOK_SECRET", cfg.WebhookSecret)
+	if cfg.WebhookTimeout, err = envDuration("WEBHOOK_TIMEOUT", cfg.WebhookTimeout); err != nil {
+		return cfg, err
+	}
+	if cfg.ReplyThreadTTL, err = envDuration("REPLY_THREAD_TTL", cfg.ReplyThreadTTL); err != nil {
+		return cfg, err
+	}
+	if cfg.AlertDigestInterval, err = envDuration("ALERT_DIGEST_INTERVAL", cfg.AlertDigestInterval); err != nil {
+		return cfg, err
+	}
+	if cfg.SkipLogEvery, err = envInt("SKIP_LOG_EVERY", cfg.SkipLogEvery); err != nil {
+		return cfg, err
+	}
+	if cfg.SkipSummaryInterval, err = envDuration("SKIP_SUMMARY_INTERVAL", cfg.SkipSummaryInterval); err != nil {
+		return cfg, err
+	}
+	if cfg.LatePriceWindow, err = envDuration("LATE_PRICE_WINDOW", cfg.LatePriceWindow); err != nil {
+		return cfg, err
+	}
+	if cfg.LatePriceInterval, err = envDuration("LATE_PRICE_INTERVAL", cfg.LatePriceInterval); err != nil {
+		return cfg, err
+	}
+	recentAddLookback, err := envInt("RECENT_ADD_LOOKBACK", int(cfg.RecentAddLookback))
+	if err != nil {
+		return cfg, err
+	}
+	if recentAddLookback < 0 {
+		return cfg, fmt.Errorf("RECENT_ADD_LOOKBACK must not be negative, got %d", recentAddLookback)
+	}
+	cfg.RecentAddLookback = uint64(recentAddLookback)
+	if cfg.MinLiquidityUSD, err = envFloat("MIN_LIQUIDITY_USD", cfg.MinLiquidityUSD); err != nil {
+		return cfg, err
+	}
+	if cfg.AlertOnUnknownLiquidity, err = envBool("ALERT_ON_UNKNOWN_LIQUIDITY", cfg.AlertOnUnknownLiquidity); err != nil {
+		return cfg, err
+	}
+	if cfg.MaxSafeTax, err = envFloat("MAX_SAFE_TAX", cfg.MaxSafeTax); err != nil {
+		return cfg, err
+	}
+	if cfg.McapSanityRatio, err = envFloat("MCAP_SANITY_RATIO", cfg.McapSanityRatio); err != nil {
+		return cfg, err
+	}
+	if cfg.McapSanityRetries, err = envInt("MCAP_SANITY_RETRIES", cfg.McapSanityRetries); err != nil {
+		return cfg, err
+	}
+	if cfg.HideSnipeOnHighTax, err = envBool("HIDE_SNIPE_ON_HIGH_TAX", cfg.HideSnipeOnHighTax); err != nil {
+		return cfg, err
+	}
+	if cfg.AlertIDs, err = envBool("ALERT_IDS", cfg.AlertIDs); err != nil {
+		return cfg, err
+	}
+	if cfg.AlertLogos, err = envBool("ALERT_LOGOS", cfg.AlertLogos); err != nil {
+		return cfg, err
+	}
+	if cfg.FooterEnabled, err = envBool("ALERT_FOOTER", cfg.FooterEnabled); err != nil {
+		return cfg, err
+	}
+	cfg.FooterText = envString("FOOTER_TEXT", cfg.FooterText)
+	cfg.FooterURL = envString("FOOTER_URL", cfg.FooterURL)
+	cfg.Language = envString("ALERT_LANGUAGE", cfg.Language)
+	cfg.LocaleDir = envString("LOCALE_DIR", cfg.LocaleDir)
+	cfg.InstanceName = envString("INSTANCE_NAME", cfg.InstanceName)
+	cfg.ArchiveNodeURL = envString("ARCHIVE_NODE_URL", cfg.ArchiveNodeURL)
+	if cfg.DryRun, err = envBool("DRY_RUN", cfg.DryRun); err != nil {
+		return cfg, err
+	}
+	cfg.BacktestFile = envString("BACKTEST_FILE", cfg.BacktestFile)
+	cfg.ReplayFile = envString("REPLAY_FILE", cfg.ReplayFile)
+	if cfg.HeartbeatInterval, err = envDuration("HEARTBEAT_INTERVAL", cfg.HeartbeatInterval); err != nil {
+		return cfg, err
+	}
+	if cfg.ReconnectDelay, err = envDuration("RECONNECT_DELAY", cfg.ReconnectDelay); err != nil {
+		return cfg, err
+	}
+	if cfg.ReconnectAlertThreshold, err = envInt("RECONNECT_ALERT_THRESHOLD", cfg.ReconnectAlertThreshold); err != nil {
+		return cfg, err
+	}
+	if cfg.ReconnectAlertWindow, err = envDuration("RECONNECT_ALERT_WINDOW", cfg.ReconnectAlertWindow); err != nil {
+		return cfg, err
+	}
+	if cfg.StableUptime, err = envDuration("STABLE_UPTIME", cfg.StableUptime); err != nil {
+		return cfg, err
+	}
+	cfg.AdminChatID = envString("ADMIN_CHAT_ID", cfg.AdminChatID)
+	if cfg.TxLookupAttempts, err = envInt("TX_LOOKUP_ATTEMPTS", cfg.TxLookupAttempts); err != nil {
+		return cfg, err
+	}
+	if cfg.TxLookupDelay, err = envDuration("TX_LOOKUP_DELAY", cfg.TxLookupDelay); err != nil {
+		return cfg, err
+	}
+	if cfg.BurnFlushWindow, err = envDuration("BURN_FLUSH_WINDOW", cfg.BurnFlushWindow); err != nil {
+		return cfg, err
+	}
+	if cfg.BurnGraceWindow, err = envDuration("BURN_GRACE_WINDOW", cfg.BurnGraceWindow); err != nil {
+		return cfg, err
+	}
+	if cfg.FullBurnPercent, err = envFloat("FULL_BURN_PERCENT", cfg.FullBurnPercent); err != nil {
+		return cfg, err
+	}
+	if cfg.WorkerCount, err = envInt("WORKERS", cfg.WorkerCount); err != nil {
+		return cfg, err
+	}
+	if cfg.WorkQueueSize, err = envInt("WORK_QUEUE_SIZE", cfg.WorkQueueSize); err != nil {
+		return cfg, err
+	}
+	if cfg.ProcessTimeout, err = envDuration("PROCESS_TIMEOUT", cfg.ProcessTimeout); err != nil {
+		return cfg, err
+	}
+	if cfg.EnrichConcurrency, err = envInt("ENRICH_CONCURRENCY", cfg.EnrichConcurrency); err != nil {
+		return cfg, err
+	}
+	if cfg.EnrichTimeout, err = envDuration("ENRICH_TIMEOUT", cfg.EnrichTimeout); err != nil {
+		return cfg, err
+	}
+	if cfg.RetryBudget, err = envInt("RETRY_BUDGET", cfg.RetryBudget); err != nil {
+		return cfg, err
+	}
+	if cfg.RetryBudgetTime, err = envDuration("RETRY_BUDGET_TIME", cfg.RetryBudgetTime); err != nil {
+		return cfg, err
+	}
+	if cfg.HTTPMaxAttempts, err = envInt("HTTP_MAX_ATTEMPTS", cfg.HTTPMaxAttempts); err != nil {
+		return cfg, err
+	}
+	if cfg.HTTPRetryBaseDelay, err = envDuration("HTTP_RETRY_BASE_DELAY", cfg.HTTPRetryBaseDelay); err != nil {
+		return cfg, err
+	}
+	if cfg.BreakerThreshold, err = envInt("BREAKER_THRESHOLD", cfg.BreakerThreshold); err != nil {
+		return cfg, err
+	}
+	if cfg.BreakerCooldown, err = envDuration("BREAKER_COOLDOWN", cfg.BreakerCooldown); err != nil {
+		return cfg, err
+	}
+	if cfg.FirstBurnOnly, err = envBool("FIRST_BURN_ONLY", cfg.FirstBurnOnly); err != nil {
+		return cfg, err
+	}
+	cfg.LPNamePatterns = envList("LP_NAME_PATTERNS", cfg.LPNamePatterns)
+	cfg.SupplyWatchPairs = envList("SUPPLY_WATCH_PAIRS", cfg.SupplyWatchPairs)
+	if cfg.SupplyDropFraction, err = envFloat("SUPPLY_DROP_FRACTION", cfg.SupplyDropFraction); err != nil {
+		return cfg, err
+	}
+	if cfg.CirculatingLPPercent, err = envBool("CIRCULATING_LP_PERCENT", cfg.CirculatingLPPercent); err != nil {
+		return cfg, err
+	}
+	cfg.BurnStorePath = envString("BURN_STORE_PATH", cfg.BurnStorePath)
+	cfg.CursorPath = envString("CURSOR_PATH", cfg.CursorPath)
+	cfg.ProcessedTxsPath = envString("PROCESSED_TXS_PATH", cfg.ProcessedTxsPath)
+	if cfg.ProcessedTxsSize, err = envInt("PROCESSED_TXS_SIZE", cfg.ProcessedTxsSize); err != nil {
+		return cfg, err
+	}
+	if cfg.ProcessedTxsTTL, err = envDuration("PROCESSED_TXS_TTL", cfg.ProcessedTxsTTL); err != nil {
+		return cfg, err
+	}
+	if cfg.CatchUpWindow, err = envDuration("CATCH_UP_WINDOW", cfg.CatchUpWindow); err != nil {
+		return cfg, err
+	}
+	if cfg.ResyncStaleAge, err = envDuration("RESYNC_STALE_AGE", cfg.ResyncStaleAge); err != nil {
+		return cfg, err
+	}
+	if cfg.ResyncSettle, err = envDuration("RESYNC_SETTLE", cfg.ResyncSettle); err != nil {
+		return cfg, err
+	}
+	if val, ok := os.LookupEnv("HTTP_ADDR"); ok {
+		cfg.HTTPAddr = val // empty disables the HTTP server
+	}
+	cfg.HolderCountSource = strings.ToLower(envString("HOLDER_COUNT_SOURCE", cfg.HolderCountSource))
+	if cfg.NodeDialAttempts, err = envInt("NODE_DIAL_ATTEMPTS", cfg.NodeDialAttempts); err != nil {
+		return cfg, err
+	}
+	if cfg.NodeDialDelay, err = envDuration("NODE_DIAL_DELAY", cfg.NodeDialDelay); err != nil {
+		return cfg, err
+	}
+	cfg.SubscriptionMode = strings.ToLower(envString("SUBSCRIPTION_MODE", cfg.SubscriptionMode))
+	if cfg.PollInterval, err = envDuration("POLL_INTERVAL", cfg.PollInterval); err != nil {
+		return cfg, err
+	}
+	cfg.EthplorerAPIKey = envString("ETHPLORER_API_KEY", cfg.EthplorerAPIKey)
+	if cfg.VerboseAlerts, err = envBool("VERBOSE_ALERTS", cfg.VerboseAlerts); err != nil {
+		return cfg, err
+	}
+	cfg.ExtraABIFile = envString("EXTRA_ABI_FILE", cfg.ExtraABIFile)
+	cfg.LPExtraViews = envList("LP_EXTRA_VIEWS", cfg.LPExtraViews)
+	if cfg.TaxSimulation, err = envBool("TAX_SIMULATION", cfg.TaxSimulation); err != nil {
+		return cfg, err
+	}
+	cfg.RouterAddr = envString("ROUTER_ADDR", cfg.RouterAddr)
+	cfg.DexScreenerChain = envString("DEXSCREENER_CHAIN", cfg.DexScreenerChain)
+	cfg.DexToolsChain = envString("DEXTOOLS_CHAIN", cfg.DexToolsChain)
+	cfg.DexSpyChain = envString("DEXSPY_CHAIN", cfg.DexSpyChain)
+	cfg.WatchEvents = envList("WATCH_EVENTS", cfg.WatchEvents)
+	if cfg.MinRemovalPercent, err = envFloat("MIN_REMOVAL_PERCENT", cfg.MinRemovalPercent); err != nil {
+		return cfg, err
+	}
+	if cfg.DropZeroValue, err = envBool("DROP_ZERO_VALUE", cfg.DropZeroValue); err != nil {
+		return cfg, err
+	}
+	if cfg.MinBurnPercent, err = envFloat("MIN_BURN_PERCENT", cfg.MinBurnPercent); err != nil {
+		return cfg, err
+	}
+	if val := envString("MIN_BURN_RAW", ""); val != "" {
+		raw, ok := new(big.Int).SetString(val, 10)
+		if !ok || raw.Sign() < 0 {
+			return cfg, fmt.Errorf("MIN_BURN_RAW must be a non-negative integer, got %q", val)
+		}
+		cfg.MinBurnRaw = raw
+	}
+	if cfg.WatchLiquidityAdds, err = envBool("WATCH_LIQUIDITY_ADDS", cfg.WatchLiquidityAdds); err != nil {
+		return cfg, err
+	}
+	callGasLimit, err := envInt("CALL_GAS_LIMIT", int(cfg.CallGasLimit))
+	if err != nil {
+		return cfg, err
+	}
+	if callGasLimit < 0 {
+		return cfg, fmt.Errorf("CALL_GAS_LIMIT must not be negative, got %d", callGasLimit)
+	}
+	cfg.CallGasLimit = uint64(callGasLimit)
+	if cfg.DEXProbe, err = envBool("DEX_PROBE", cfg.DEXProbe); err != nil {
+		return cfg, err
+	}
+	if cfg.ENSLookup, err = envBool("ENS_LOOKUP", cfg.ENSLookup); err != nil {
+		return cfg, err
+	}
+	if cfg.TopHolders, err = envInt("TOP_HOLDERS", cfg.TopHolders); err != nil {
+		return cfg, err
+	}
+	cfg.ExplorerURL = strings.TrimSuffix(envString("EXPLORER_URL", cfg.ExplorerURL), "/")
+	cfg.ENSRegistry = envString("ENS_REGISTRY", cfg.ENSRegistry)
+	if cfg.QuotePriceUSD, err = envFloat("QUOTE_PRICE_USD", cfg.QuotePriceUSD); err != nil {
+		return cfg, err
+	}
+	cfg.QuotePriceOracle = envString("QUOTE_PRICE_ORACLE", cfg.QuotePriceOracle)
+	cfg.GeckoNetwork = envString("GECKO_NETWORK", cfg.GeckoNetwork)
+	cfg.StableAddrs = envList("STABLE_ADDRS", cfg.StableAddrs)
+	quoteDecimals, err := envMap("QUOTE_DECIMALS", nil)
+	if err != nil {
+		return cfg, err
+	}
+	if cfg.FloatPrecision, err = envInt("FLOAT_PRECISION", cfg.FloatPrecision); err != nil {
+		return cfg, err
+	}
+	riskWeights, err := envMap("RISK_WEIGHTS", nil)
+	if err != nil {
+		return cfg, err
+	}
+	if cfg.RiskCautionScore, err = envInt("RISK_CAUTION_SCORE", cfg.RiskCautionScore); err != nil {
+		return cfg, err
+	}
+	if cfg.RiskDangerScore, err = envInt("RISK_DANGER_SCORE", cfg.RiskDangerScore); err != nil {
+		return cfg, err
+	}
+	if cfg.RiskConcentrationPercent, err = envFloat("RISK_CONCENTRATION_PERCENT", cfg.RiskConcentrationPercent); err != nil {
+		return cfg, err
+	}
+	if cfg.RiskMinBurnPercent, err = envFloat("RISK_MIN_BURN_PERCENT", cfg.RiskMinBurnPercent); err != nil {
+		return cfg, err
+	}
+	if cfg.QuotePriceTTL, err = envDuration("QUOTE_PRICE_TTL", cfg.QuotePriceTTL); err != nil {
+		return cfg, err
+	}
+	if cfg.GoPlusSupported, err = envBool("GOPLUS_SUPPORTED", cfg.GoPlusSupported); err != nil {
+		return cfg, err
+	}
+	if cfg.GeckoSupported, err = envBool("GECKO_SUPPORTED", cfg.GeckoSupported); err != nil {
+		return cfg, err
+	}
+
+	if cfg.DeadAddr, err = normalizeAddress("DEAD_ADDR", cfg.DeadAddr); err != nil {
+		return cfg, err
+	}
+	if cfg.WethAddr, err = normalizeAddress("WETH_ADDR", cfg.WethAddr); err != nil {
+		return cfg, err
+	}
+	if cfg.RouterAddr, err = normalizeAddress("ROUTER_ADDR", cfg.RouterAddr); err != nil {
+		return cfg, err
+	}
+	if cfg.ENSRegistry, err = normalizeAddress("ENS_REGISTRY", cfg.ENSRegistry); err != nil {
+		return cfg, err
+	}
+	if cfg.QuotePriceOracle != "" {
+		if cfg.QuotePriceOracle, err = normalizeAddress("QUOTE_PRICE_ORACLE", cfg.QuotePriceOracle); err != nil {
+			return cfg, err
+		}
+	}
+	if cfg.FromAddrs, err = normalizeAddressList("FROM_ADDRS", cfg.FromAddrs); err != nil {
+		return cfg, err
+	}
+	if cfg.SupplyWatchPairs, err = normalizeAddressList("SUPPLY_WATCH_PAIRS", cfg.SupplyWatchPairs); err != nil {
+		return cfg, err
+	}
+	if cfg.ProjectTokens, err = normalizeAddressList("PROJECT_TOKENS", cfg.ProjectTokens); err != nil {
+		return cfg, err
+	}
+	overrides := make(map[string]string, len(cfg.PoolProjectTokens))
+	for pool, token := range cfg.PoolProjectTokens {
+		poolAddr, err := normalizeAddress("POOL_PROJECT_TOKENS", pool)
+		if err != nil {
+			return cfg, err
+		}
+		if overrides[poolAddr], err = normalizeAddress("POOL_PROJECT_TOKENS", token); err != nil {
+			return cfg, err
+		}
+	}
+	cfg.PoolProjectTokens = overrides
+	if cfg.StableAddrs, err = normalizeAddressList("STABLE_ADDRS", cfg.StableAddrs); err != nil {
+		return cfg, err
+	}
+	if cfg.FloatPrecision < 64 || cfg.FloatPrecision > 4096 {
+		return cfg, fmt.Errorf("FLOAT_PRECISION must be between 64 and 4096 bits, got %d", cfg.FloatPrecision)
+	}
+
+	// RISK_WEIGHTS entries override the default weight of their signal
+	for signal, value := range riskWeights {
+		if _, ok := cfg.RiskWeights[signal]; !ok {
+			return cfg, fmt.Errorf("invalid RISK_WEIGHTS signal %q: want one of %s", signal, strings.Join(riskSignals, ", "))
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight < 0 {
+			return cfg, fmt.Errorf("RISK_WEIGHTS must map signals to non-negative integers, got %q for %s", value, signal)
+		}
+		cfg.RiskWeights[signal] = weight
+	}
+	if cfg.RiskCautionScore < 1 || cfg.RiskDangerScore < cfg.RiskCautionScore {
+		return cfg, fmt.Errorf("RISK_CAUTION_SCORE must be at least 1 and at most RISK_DANGER_SCORE, got %d and %d", cfg.RiskCautionScore, cfg.RiskDangerScore)
+	}
+	if cfg.RiskConcentrationPercent <= 0 || cfg.RiskConcentrationPercent > 100 {
+		return cfg, fmt.Errorf("RISK_CONCENTRATION_PERCENT must be above 0 and at most 100, got %g", cfg.RiskConcentrationPercent)
+	}
+	if cfg.MinBurnPercent < 0 || cfg.MinBurnPercent > 100 {
+		return cfg, fmt.Errorf("MIN_BURN_PERCENT must be between 0 and 100, got %g", cfg.MinBurnPercent)
+	}
+	if cfg.RiskMinBurnPercent < 0 || cfg.RiskMinBurnPercent > 100 {
+		return cfg, fmt.Errorf("RISK_MIN_BURN_PERCENT must be between 0 and 100, got %g", cfg.RiskMinBurnPercent)
+	}
+
+	// QUOTE_DECIMALS entries add to or override the built-in ones
+	for token, value := range quoteDecimals {
+		addr, err := normalizeAddress("QUOTE_DECIMALS", token)
+		if err != nil {
+			return cfg, err
+		}
+		decimals, err := strconv.ParseUint(value, 10, 8)
+		if err != nil {
+			return cfg, fmt.Errorf("QUOTE_DECIMALS must map addresses to decimals from 0 to 255, got %q for %s", value, token)
+		}
+		cfg.QuoteDecimals[addr] = uint8(decimals)
+	}
+
+	switch cfg.HolderCountSource {
+	case HolderSourceGoPlus, HolderSourceExplorer, HolderSourceBoth:
+	default:
+		return cfg, fmt.Errorf("invalid HOLDER_COUNT_SOURCE %q: want goplus, explorer or both", cfg.HolderCountSource)
+	}
+	if cfg.NodeDialAttempts < 0 {
+		return cfg, fmt.Errorf("NODE_DIAL_ATTEMPTS must not be negative, got %d", cfg.NodeDialAttempts)
+	}
+	if cfg.NodeDialDelay <= 0 {
+		return cfg, fmt.Errorf("NODE_DIAL_DELAY must be positive, got %s", cfg.NodeDialDelay)
+	}
+	switch cfg.SubscriptionMode {
+	case SubscriptionAuto, SubscriptionWS, SubscriptionPoll:
+	default:
+		return cfg, fmt.Errorf("invalid SUBSCRIPTION_MODE %q: want auto, ws or poll", cfg.SubscriptionMode)
+	}
+	if cfg.PollInterval <= 0 {
+		return cfg, fmt.Errorf("POLL_INTERVAL must be positive, got %s", cfg.PollInterval)
+	}
+
+	// Without GeckoTerminal nothing else prices the wrapped native token
+	if !cfg.GeckoSupported && cfg.QuotePriceUSD <= 0 && cfg.QuotePriceOracle == "" {
+		return cfg, fmt.Errorf("QUOTE_PRICE_USD or QUOTE_PRICE_ORACLE must be set when GECKO_SUPPORTED is false")
+	}
+
+	if cfg.TxLookupAttempts < 1 {
+		return cfg, fmt.Errorf("TX_LOOKUP_ATTEMPTS must be at least 1, got %d", cfg.TxLookupAttempts)
+	}
+
+	if cfg.HTTPMaxAttempts < 1 {
+		return cfg, fmt.Errorf("HTTP_MAX_ATTEMPTS must be at least 1, got %d", cfg.HTTPMaxAttempts)
+	}
+	if cfg.HTTPRetryBaseDelay <= 0 {
+		return cfg, fmt.Errorf("HTTP_RETRY_BASE_DELAY must be positive, got %s", cfg.HTTPRetryBaseDelay)
+	}
+
+	if cfg.PrefetchConcurrency < 1 {
+		return cfg, fmt.Errorf("PREFETCH_CONCURRENCY must be at least 1, got %d", cfg.PrefetchConcurrency)
+	}
+	if cfg.MetadataRefreshInterval <= 0 {
+		return cfg, fmt.Errorf("METADATA_REFRESH_INTERVAL must be positive, got %s", cfg.MetadataRefreshInterval)
+	}
+	if cfg.BurnGraceWindow < 0 {
+		return cfg, fmt.Errorf("BURN_GRACE_WINDOW must not be negative, got %s", cfg.BurnGraceWindow)
+	}
+	if cfg.FullBurnPercent <= 0 || cfg.FullBurnPercent > 100 {
+		return cfg, fmt.Errorf("FULL_BURN_PERCENT must be above 0 and at most 100, got %g", cfg.FullBurnPercent)
+	}
+	if cfg.WorkerCount < 1 {
+		return cfg, fmt.Errorf("WORKERS must be at least 1, got %d", cfg.WorkerCount)
+	}
+	if cfg.WorkQueueSize < 1 {
+		return cfg, fmt.Errorf("WORK_QUEUE_SIZE must be at least 1, got %d", cfg.WorkQueueSize)
+	}
+
+	if cfg.EnrichConcurrency < 1 {
+		return cfg, fmt.Errorf("ENRICH_CONCURRENCY must be at least 1, got %d", cfg.EnrichConcurrency)
+	}
+	if cfg.EnrichTimeout <= 0 {
+		return cfg, fmt.Errorf("ENRICH_TIMEOUT must be positive, got %s", cfg.EnrichTimeout)
+	}
+
+	if cfg.RetryBudget < 0 {
+		return cfg, fmt.Errorf("RETRY_BUDGET must not be negative, got %d", cfg.RetryBudget)
+	}
+	if cfg.RetryBudgetTime < 0 {
+		return cfg, fmt.Errorf("RETRY_BUDGET_TIME must not be negative, got %s", cfg.RetryBudgetTime)
+	}
+
+	if cfg.BreakerThreshold < 1 {
+		return cfg, fmt.Errorf("BREAKER_THRESHOLD must be at least 1, got %d", cfg.BreakerThreshold)
+	}
+
+	if cfg.SkipLogEvery < 0 {
+		return cfg, fmt.Errorf("SKIP_LOG_EVERY must not be negative, got %d", cfg.SkipLogEvery)
+	}
+	if cfg.SkipSummaryInterval <= 0 {
+		return cfg, fmt.Errorf("SKIP_SUMMARY_INTERVAL must be positive, got %s", cfg.SkipSummaryInterval)
+	}
+
+	if cfg.LatePriceWindow > 0 && cfg.LatePriceInterval <= 0 {
+		return cfg, fmt.Errorf("LATE_PRICE_INTERVAL must be positive, got %s", cfg.LatePriceInterval)
+	}
+	if cfg.McapSanityRatio != 0 && cfg.McapSanityRatio < 1 {
+		return cfg, fmt.Errorf("MCAP_SANITY_RATIO must be 0 or at least 1, got %g", cfg.McapSanityRatio)
+	}
+	if cfg.McapSanityRetries < 0 {
+		return cfg, fmt.Errorf("MCAP_SANITY_RETRIES must not be negative, got %d", cfg.McapSanityRetries)
+	}
+
+	if cfg.MaxSafeTax < 0 || cfg.MaxSafeTax > 1 {
+		return cfg, fmt.Errorf("MAX_SAFE_TAX must be a fraction between 0 and 1, got %g", cfg.MaxSafeTax)
+	}
+	if cfg.MinRemovalPercent < 0 || cfg.MinRemovalPercent > 100 {
+		return cfg, fmt.Errorf("MIN_REMOVAL_PERCENT must be between 0 and 100, got %g", cfg.MinRemovalPercent)
+	}
+
+	if len(cfg.WatchEvents) == 0 {
+		return cfg, fmt.Errorf("WATCH_EVENTS must name at least one event")
+	}
+	for i, event := range cfg.WatchEvents {
+		cfg.WatchEvents[i] = strings.ToLower(event)
+		switch cfg.WatchEvents[i] {
+		case EventDeadTransfer, EventV2Burn, EventV3Burn:
+		default:
+			return cfg, fmt.Errorf("invalid WATCH_EVENTS entry %q: must be %s, %s or %s", event, EventDeadTransfer, EventV2Burn, EventV3Burn)
+		}
+	}
+
+	if cfg.HeartbeatInterval < 0 {
+		return cfg, fmt.Errorf("HEARTBEAT_INTERVAL must not be negative, got %s", cfg.HeartbeatInterval)
+	}
+
+	if cfg.ReconnectDelay <= 0 {
+		return cfg, fmt.Errorf("RECONNECT_DELAY must be positive, got %s", cfg.ReconnectDelay)
+	}
+	if cfg.ReconnectAlertThreshold < 0 {
+		return cfg, fmt.Errorf("RECONNECT_ALERT_THRESHOLD must not be negative, got %d", cfg.ReconnectAlertThreshold)
+	}
+	if cfg.ReconnectAlertWindow <= 0 {
+		return cfg, fmt.Errorf("RECONNECT_ALERT_WINDOW must be positive, got %s", cfg.ReconnectAlertWindow)
+	}
+	if cfg.StableUptime <= 0 {
+		return cfg, fmt.Errorf("STABLE_UPTIME must be positive, got %s", cfg.StableUptime)
+	}
+
+	if (cfg.CanaryToken == "") != (cfg.CanaryPool == "") {
+		return cfg, fmt.Errorf("CANARY_TOKEN and CANARY_POOL must be set together")
+	}
+	if cfg.CanaryToken != "" {
+		if cfg.CanaryToken, err = normalizeAddress("CANARY_TOKEN", cfg.CanaryToken); err != nil {
+			return cfg, err
+		}
+		if cfg.CanaryPool, err = normalizeAddress("CANARY_POOL", cfg.CanaryPool); err != nil {
+			return cfg, err
+		}
+		if cfg.CanaryInterval <= 0 {
+			return cfg, fmt.Errorf("CANARY_INTERVAL must be positive, got %s", cfg.CanaryInterval)
+		}
+	}
+
+	if cfg.SlackWebhookURL != "" && !strings.HasPrefix(cfg.SlackWebhookURL, "https://") {
+		return cfg, fmt.Errorf("SLACK_WEBHOOK_URL must be an https URL")
+	}
+	if cfg.SlackTimeout <= 0 {
+		return cfg, fmt.Errorf("SLACK_TIMEOUT must be positive, got %s", cfg.SlackTimeout)
+	}
+	if cfg.WebhookURL != "" && !strings.HasPrefix(cfg.WebhookURL, "https://") && !strings.HasPrefix(cfg.WebhookURL, "http://") {
+		return cfg, fmt.Errorf("WEBHOOK_URL must be an http or https URL")
+	}
+	if cfg.WebhookTimeout <= 0 {
+		return cfg, fmt.Errorf("WEBHOOK_TIMEOUT must be positive, got %s", cfg.WebhookTimeout)
+	}
+
+	if cfg.FooterURL != "" && !strings.HasPrefix(cfg.FooterURL, "https://") && !strings.HasPrefix(cfg.FooterURL, "http://") {
+		return cfg, fmt.Errorf("FOOTER_URL must be an http(s) URL, got %q", cfg.FooterURL)
+	}
+
+	if cfg.ProcessedTxsSize < 1 {
+		return cfg, fmt.Errorf("PROCESSED_TXS_SIZE must be at least 1, got %d", cfg.ProcessedTxsSize)
+	}
+	if cfg.ProcessedTxsTTL < 0 {
+		return cfg, fmt.Errorf("PROCESSED_TXS_TTL must not be negative, got %s", cfg.ProcessedTxsTTL)
+	}
+
+	if cfg.SupplyDropFraction <= 0 || cfg.SupplyDropFraction > 1 {
+		return cfg, fmt.Errorf("SUPPLY_DROP_FRACTION must be a fraction above 0 and at most 1, got %g", cfg.SupplyDropFraction)
+	}
+
+	if len(cfg.LPNamePatterns) == 0 {
+		return cfg, fmt.Errorf("LP_NAME_PATTERNS must not be empty")
+	}
+
+	if cfg.TopHolders < 0 || cfg.TopHolders > maxTopHolders {
+		return cfg, fmt.Errorf("TOP_HOLDERS must be between 0 and %d, got %d", maxTopHolders, cfg.TopHolders)
+	}
+
+	if cfg.ResyncStaleAge < 0 {
+		return cfg, fmt.Errorf("RESYNC_STALE_AGE must not be negative, got %s", cfg.ResyncStaleAge)
+	}
+	if cfg.ResyncSettle < 0 {
+		return cfg, fmt.Errorf("RESYNC_SETTLE must not be negative, got %s", cfg.ResyncSettle)
+	}
+
+	if cfg.ReplyThreadTTL < 0 {
+		return cfg, fmt.Errorf("REPLY_THREAD_TTL must not be negative, got %s", cfg.ReplyThreadTTL)
+	}
+
+	if cfg.AlertDigestInterval < 0 {
+		return cfg, fmt.Errorf("ALERT_DIGEST_INTERVAL must not be negative, got %s", cfg.AlertDigestInterval)
+	}
+
+	if cfg.TelegramQueueSize < 1 {
+		return cfg, fmt.Errorf("TELEGRAM_QUEUE_SIZE must be at least 1, got %d", cfg.TelegramQueueSize)
+	}
+
+	return cfg, nil
+}
+
+// normalizeAddress validates a configured address and returns it in the
+// lowercase 0x-prefixed form used for comparisons. Mixed-case input must
+// carry a valid EIP-55 checksum.
+func normalizeAddress(name, value string) (string, error) {
+	if !common.IsHexAddress(value) {
+		return "", fmt.Errorf("invalid %s: %q is not a hex address", name, value)
+	}
+	hexPart := strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	if hexPart != strings.ToLower(hexPart) && hexPart != strings.ToUpper(hexPart) {
+		mixed, err := common.NewMixedcaseAddressFromString("0x" + hexPart)
+		if err != nil || !mixed.ValidChecksum() {
+			return "", fmt.Errorf("invalid %s: %q has a bad checksum", name, value)
+		}
+	}
+	return strings.ToLower(common.HexToAddress(value).Hex()), nil
+}
+
+func normalizeAddressList(name string, values []string) ([]string, error) {
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		addr, err := normalizeAddress(name, value)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, addr)
+	}
+	return normalized, nil
+}
+
+func envString(key, def string) string {
+	if val, ok := os.LookupEnv(key); ok && val != "" {
+		return val
+	}
+	return def
+}
+
+// envList reads a comma-separated list, ignoring empty entries.
+func envList(key string, def []string) []string {
+	val, ok := os.LookupEnv(key)
+	if !ok {
+		return def
+	}
+	var list []string
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// envMap reads a comma-separated list of key:value pairs.
+func envMap(key string, def map[string]string) (map[string]string, error) {
+	if _, ok := os.LookupEnv(key); !ok {
+		return def, nil
+	}
+	m := make(map[string]string)
+	for _, item := range envList(key, nil) {
+		k, v, ok := strings.Cut(item, ":")
+		if !ok {
+			return def, fmt.Errorf("invalid %s entry %q: want key:value", key, item)
+		}
+		m[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return m, nil
+}
+
+func envInt(key string, def int) (int, error) {
+	val, ok := os.LookupEnv(key)
+	if !ok || val == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		return def, fmt.Errorf("invalid %s %q: %v", key, val, err)
+	}
+	return n, nil
+}
+
+func envFloat(key string, def float64) (float64, error) {
+	val, ok := os.LookupEnv(key)
+	if !ok || val == "" {
+		return def, nil
+	}
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return def, fmt.Errorf("invalid %s %q: %v", key, val, err)
+	}
+	return f, nil
+}
+
+func envBool(key string, def bool) (bool, error) {
+	val, ok := os.LookupEnv(key)
+	if !ok || val == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		return def, fmt.Errorf("invalid %s %q: %v", key, val, err)
+	}
+	return b, nil
+}
+
+func envDuration(key string, def time.Duration) (time.Duration, error) {
+	val, ok := os.LookupEnv(key)
+	if !ok || val == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return def, fmt.Errorf("invalid %s %q: %v", key, val, err)
+	}
+	return d, nil
+}
+
+// secretConfigFields are blanked out by effectiveConfig; node URLs often
+// carry a provider API key.
+var secretConfigFields = map[string]bool{
+	"EthplorerAPIKey": true,
+	"ArchiveNodeURL":  true,
+	"SlackWebhookURL": true,
+	"WebhookURL":      true,
+	"WebhookSecret":   true,
+}
+
+// effectiveConfig renders cfg for -print-config: fields by name, durations
+// as strings and secrets redacted.
+func effectiveConfig(cfg Config) map[string]interface{} {
+	out := make(map[string]interface{})
+	v := reflect.ValueOf(cfg)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Name
+		field := v.Field(i).Interface()
+		switch {
+		case secretConfigFields[name]:
+			if v.Field(i).String() != "" {
+				field = "[redacted]"
+			}
+		case t.Field(i).Type == reflect.TypeOf(time.Duration(0)):
+			field = field.(time.Duration).String()
+		}
+		out[name] = field
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// catchUpChunk is how many blocks each catch-up eth_getLogs call covers;
+// most providers reject much larger ranges.
+const catchUpChunk = 2000
+
+// BlockCursor persists the last block whose burns were seen, so a restart
+// can catch up on what it missed.
+type BlockCursor struct {
+	path string
+}
+
+func NewBlockCursor(path string) *BlockCursor {
+	return &BlockCursor{path: path}
+}
+
+// Load returns the stored block. ok is false when nothing is stored yet.
+func (c *BlockCursor) Load() (block uint64, ok bool, err error) {
+	data, err := os.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read block cursor: %v", err)
+	}
+
+	block, err = strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid block cursor %q: %v", strings.TrimSpace(string(data)), err)
+	}
+	return block, true, nil
+}
+
+func (c *BlockCursor) Save(block uint64) error {
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(block, 10)+"\n"), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// shouldCatchUp decides whether a cursor written at cursorTime is recent
+// enough to scan forward from. Older cursors would mean scanning a huge
+// range for burns too stale to alert on, so the detector just goes live.
+func shouldCatchUp(cursorTime, now time.Time, window time.Duration) bool {
+	return window > 0 && now.Sub(cursorTime) <= window
+}
+
+// catchUp processes the burns between the stored cursor and the current
+// head, if the cursor is recent enough. It is a no-op without a cursor.
+func (d *LPBurnDetector) catchUp(ctx context.Context) error {
+	cursor, ok, err := d.cursor.Load()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		log.Println("⏩ No block cursor stored, starting live")
+		return nil
+	}
+
+	head, err := d.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get head block: %v", err)
+	}
+	if cursor >= head {
+		return nil
+	}
+
+	header, err := d.client.HeaderByNumber(ctx, new(big.Int).SetUint64(cursor))
+	if err != nil {
+		return fmt.Errorf("failed to get cursor block: %v", err)
+	}
+	cursorTime := time.Unix(int64(header.Time), 0)
+	if !shouldCatchUp(cursorTime, d.clock.Now(), d.config.CatchUpWindow) {
+		log.Printf("⏩ Block cursor %d is %s old (window %s), skipping catch-up and starting live",
+			cursor, d.clock.Now().Sub(cursorTime).Round(time.Minute), d.config.CatchUpWindow)
+		return d.cursor.Save(head)
+	}
+
+	log.Printf("⏪ Catching up on blocks %d to %d", cursor+1, head)
+
+	queries := d.watchQueries()
+	for from := cursor + 1; from <= head; from += catchUpChunk {
+		to := from + catchUpChunk - 1
+		if to > head {
+			to = head
+		}
+
+		var logs []types.Log
+		for _, query := range queries {
+			query.FromBlock = new(big.Int).SetUint64(from)
+			query.ToBlock = new(big.Int).SetUint64(to)
+
+			chunk, err := d.client.FilterLogs(ctx, query)
+			if err != nil {
+				return fmt.Errorf("failed to get logs for blocks %d-%d: %v", from, to, err)
+			}
+			logs = append(logs, chunk...)
+		}
+		d.processCatchUpLogs(logs)
+
+		if err := d.cursor.Save(to); err != nil {
+			log.Printf("Failed to save block cursor: %v", err)
+		}
+	}
+
+	log.Printf("⏪ Caught up to block %d", head)
+	return nil
+}
+
+// processCatchUpLogs runs each tx's logs through processLPBurn in block
+// order, as watchLogs would have.
+func (d *LPBurnDetector) processCatchUpLogs(logs []types.Log) {
+	byTx := make(map[string][]types.Log)
+	var order []string
+	for _, vLog := range logs {
+		key := vLog.TxHash.Hex()
+		if _, ok := byTx[key]; !ok {
+			order = append(order, key)
+		}
+		byTx[key] = append(byTx[key], vLog)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return byTx[order[i]][0].BlockNumber < byTx[order[j]][0].BlockNumber
+	})
+
+	for _, key := range order {
+		batch := byTx[key]
+		txHash := batch[0].TxHash
+		blockNumber := new(big.Int).SetUint64(batch[0].BlockNumber)
+		if d.processed.Has(txHash) {
+			d.stats.skips.Add(1)
+			d.skips.log(skipf("tx %s already processed", txHash.Hex()))
+			continue
+		}
+
+		ctx, cancel := d.newEventContext()
+		err := d.processLPBurn(ctx, txHash, blockNumber, batch)
+		cancel()
+		if isSkip(err) {
+			d.stats.skips.Add(1)
+			d.skips.log(err)
+		} else if err != nil {
+			logf(ctx, "❌ Failed to process tx %s: %v", txHash.Hex(), err)
+		} else {
+			d.stats.burns.Add(1)
+			logf(ctx, "🔥 LP burn detected during catch-up and alert queued!")
+			d.markProcessed(ctx, txHash)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestShouldCatchUp(t *testing.T) {
+	clock := newFakeClock()
+	cursorTime := clock.Now()
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		window  time.Duration
+		want    bool
+	}{
+		{"within window", 30 * time.Minute, time.Hour, true},
+		{"at window", time.Hour, time.Hour, true},
+		{"past window", time.Hour + time.Second, time.Hour, false},
+		{"catch-up disabled", time.Minute, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := cursorTime.Add(tt.elapsed)
+			if got := shouldCatchUp(cursorTime, now, tt.window); got != tt.want {
+				t.Errorf("shouldCatchUp(+%s, window %s) = %v, want %v", tt.elapsed, tt.window, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatchUp(t *testing.T) {
+	const cursorBlock, headBlock = 90, 100
+
+	tests := []struct {
+		name        string
+		cursorAge   time.Duration
+		wantScanned bool
+	}{
+		{"recent cursor catches up", 10 * time.Minute, true},
+		{"stale cursor skips to live", 2 * time.Hour, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, node := newTestDetector(t, nil)
+			clock := newFakeClock()
+			d.clock = clock
+			d.config.CatchUpWindow = time.Hour
+			d.cursor = NewBlockCursor(filepath.Join(t.TempDir(), "cursor"))
+			if err := d.cursor.Save(cursorBlock); err != nil {
+				t.Fatal(err)
+			}
+
+			cursorTime := clock.Now().Add(-tt.cursorAge)
+			node.handle("eth_blockNumber", func([]json.RawMessage) (interface{}, error) {
+				return hexutil.Uint64(headBlock), nil
+			})
+			node.handle("eth_getBlockByNumber", func([]json.RawMessage) (interface{}, error) {
+				return &types.Header{
+					Number:     big.NewInt(cursorBlock),
+					Time:       uint64(cursorTime.Unix()),
+					Difficulty: big.NewInt(0),
+				}, nil
+			})
+			node.handle("eth_getLogs", func([]json.RawMessage) (interface{}, error) {
+				return []types.Log{}, nil
+			})
+
+			if err := d.catchUp(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if scanned := node.called("eth_getLogs") > 0; scanned != tt.wantScanned {
+				t.Errorf("scanned logs = %v, want %v", scanned, tt.wantScanned)
+			}
+			if block, _, _ := d.cursor.Load(); block != headBlock {
+				t.Errorf("cursor = %d, want %d", block, headBlock)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// decodeLenient unmarshals the JSON in data into v like json.Unmarshal, but
+// one field at a time: a field whose value doesn't fit its Go type is logged
+// and left zero while the rest of the payload is kept, so an upstream API
+// changing one field's type doesn't lose the whole response. Only invalid
+// JSON is an error.
+func decodeLenient(ctx context.Context, what string, data []byte, v interface{}) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("invalid %s JSON", what)
+	}
+	decodeValue(ctx, what, data, reflect.ValueOf(v).Elem())
+	return nil
+}
+
+func decodeValue(ctx context.Context, path string, data []byte, v reflect.Value) {
+	// Types with their own decoding (flexInt, ...) are decoded whole
+	if _, ok := v.Addr().Interface().(json.Unmarshaler); !ok {
+		switch v.Kind() {
+		case reflect.Struct:
+			decodeStruct(ctx, path, data, v)
+			return
+		case reflect.Slice:
+			if v.Type().Elem().Kind() != reflect.Uint8 {
+				decodeSlice(ctx, path, data, v)
+				return
+			}
+		case reflect.Map:
+			if v.Type().Key().Kind() == reflect.String {
+				decodeMap(ctx, path, data, v)
+				return
+			}
+		}
+	}
+
+	if err := json.Unmarshal(data, v.Addr().Interface()); err != nil {
+		logf(ctx, "Ignoring %s: %v", path, err)
+	}
+}
+
+func decodeStruct(ctx context.Context, path string, data []byte, v reflect.Value) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		logf(ctx, "Ignoring %s: %v", path, err)
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if raw, ok := fields[name]; ok {
+			decodeValue(ctx, path+"."+name, raw, v.Field(i))
+		}
+	}
+}
+
+func decodeSlice(ctx context.Context, path string, data []byte, v reflect.Value) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		logf(ctx, "Ignoring %s: %v", path, err)
+		return
+	}
+	if items == nil {
+		return
+	}
+
+	slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+	for i, raw := range items {
+		decodeValue(ctx, fmt.Sprintf("%s[%d]", path, i), raw, slice.Index(i))
+	}
+	v.Set(slice)
+}
+
+func decodeMap(ctx context.Context, path string, data []byte, v reflect.Value) {
+	var items map[string]json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		logf(ctx, "Ignoring %s: %v", path, err)
+		return
+	}
+	if items == nil {
+		return
+	}
+
+	m := reflect.MakeMapWithSize(v.Type(), len(items))
+	for key, raw := range items {
+		elem := reflect.New(v.Type().Elem()).Elem()
+		decodeValue(ctx, path+"."+key, raw, elem)
+		m.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+	}
+	v.Set(m)
+}
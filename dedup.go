@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ProcessedTxs remembers the txs already alerted on, so a tx that both the
+// catch-up scan and the live subscription deliver alerts once. Entries are
+// forgotten once older than ttl, and oldest first beyond maxSize. Each entry
+// is appended to a file, which is compacted on open and whenever forgotten
+// entries make up more than half of it.
+type ProcessedTxs struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int
+	ttl     time.Duration
+	clock   Clock
+	seenAt  map[common.Hash]time.Time
+	order   []common.Hash // insertion order, oldest first
+	stale   int           // lines in the file no longer in order
+}
+
+func OpenProcessedTxs(path string, maxSize int, ttl time.Duration, clock Clock) (*ProcessedTxs, error) {
+	p := &ProcessedTxs{
+		path:    path,
+		maxSize: maxSize,
+		ttl:     ttl,
+		clock:   clock,
+		seenAt:  make(map[common.Hash]time.Time),
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open processed tx store: %v", err)
+	}
+
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			log.Printf("Skipping malformed processed tx on line %d", line)
+			continue
+		}
+		secs, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			log.Printf("Skipping malformed processed tx on line %d: %v", line, err)
+			continue
+		}
+		p.insert(common.HexToHash(fields[0]), time.Unix(secs, 0))
+	}
+	file.Close()
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read processed tx store: %v", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.evict()
+	if err := p.rewrite(); err != nil {
+		return nil, fmt.Errorf("failed to compact processed tx store: %v", err)
+	}
+	return p, nil
+}
+
+// Has reports whether txHash was processed within the TTL.
+func (p *ProcessedTxs) Has(txHash common.Hash) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	at, ok := p.seenAt[txHash]
+	return ok && !p.expired(at)
+}
+
+func (p *ProcessedTxs) Add(txHash common.Hash) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	if p.insert(txHash, now) {
+		p.stale++
+	}
+	p.stale += p.evict()
+	if p.stale > len(p.order) {
+		return p.rewrite()
+	}
+
+	file, err := os.OpenFile(p.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "%s %d\n", txHash.Hex(), now.Unix())
+	return err
+}
+
+func (p *ProcessedTxs) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.seenAt)
+}
+
+// insert records txHash as seen at at and reports whether it replaced an
+// earlier entry.
+func (p *ProcessedTxs) insert(txHash common.Hash, at time.Time) bool {
+	_, ok := p.seenAt[txHash]
+	if ok {
+		for i, h := range p.order {
+			if h == txHash {
+				p.order = append(p.order[:i], p.order[i+1:]...)
+				break
+			}
+		}
+	}
+	p.seenAt[txHash] = at
+	p.order = append(p.order, txHash)
+	return ok
+}
+
+func (p *ProcessedTxs) expired(at time.Time) bool {
+	return p.ttl > 0 && p.clock.Now().Sub(at) > p.ttl
+}
+
+// evict drops expired entries and then the oldest beyond maxSize, and
+// returns how many it dropped. The caller must hold p.mu.
+func (p *ProcessedTxs) evict() int {
+	drop := 0
+	for drop < len(p.order) && (p.expired(p.seenAt[p.order[drop]]) || len(p.order)-drop > p.maxSize) {
+		delete(p.seenAt, p.order[drop])
+		drop++
+	}
+	p.order = p.order[drop:]
+	return drop
+}
+
+// rewrite replaces the file with the entries still held. The caller must
+// hold p.mu.
+func (p *ProcessedTxs) rewrite() error {
+	var b strings.Builder
+	for _, txHash := range p.order {
+		fmt.Fprintf(&b, "%s %d\n", txHash.Hex(), p.seenAt[txHash].Unix())
+	}
+
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(b.String()), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, p.path); err != nil {
+		return err
+	}
+	p.stale = 0
+	return nil
+}
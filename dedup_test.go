@@ -0,0 +1,42 @@
+package main
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestProcessedTxsCompactsOnEviction(t *testing.T) {
+	txHash := func(i int) common.Hash { return common.BigToHash(big.NewInt(int64(i))) }
+	path := filepath.Join(t.TempDir(), "processed.txt")
+	p, err := OpenProcessedTxs(path, 3, 0, newFakeClock())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 20; i++ {
+		if err := p.Add(txHash(i)); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Evicted lines may linger, but never outnumber the live ones
+		if lines := strings.Count(string(data), "\n"); lines > 2*3 {
+			t.Fatalf("after %d adds the file has %d lines, want at most 6", i+1, lines)
+		}
+	}
+
+	reopened, err := OpenProcessedTxs(path, 3, 0, newFakeClock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Len() != 3 || !reopened.Has(txHash(19)) {
+		t.Errorf("reopened store has %d entries, want the last 3", reopened.Len())
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DetailsProvider is a source of token metadata and security data.
+type DetailsProvider interface {
+	Name() string
+	TokenDetails(ctx context.Context, address string) (*TokenDetails, error)
+}
+
+type goPlusProvider struct {
+	d *LPBurnDetector
+}
+
+func (p goPlusProvider) Name() string {
+	return "GoPlus"
+}
+
+func (p goPlusProvider) TokenDetails(ctx context.Context, address string) (*TokenDetails, error) {
+	return p.d.getTokenDetails(ctx, address)
+}
+
+type DexScreenerToken struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+	Symbol  string `json:"symbol"`
+}
+
+type DexScreenerPair struct {
+	ChainID     string           `json:"chainId"`
+	PairAddress string           `json:"pairAddress"`
+	BaseToken   DexScreenerToken `json:"baseToken"`
+	QuoteToken  DexScreenerToken `json:"quoteToken"`
+	PriceUsd    string           `json:"priceUsd"`
+	Liquidity   struct {
+		USD float64 `json:"usd"`
+	} `json:"liquidity"`
+	Info struct {
+		ImageURL string `json:"imageUrl"`
+		Websites []struct {
+			Label string `json:"label"`
+			URL   string `json:"url"`
+		} `json:"websites"`
+		Socials []struct {
+			Type string `json:"type"`
+			URL  string `json:"url"`
+		} `json:"socials"`
+	} `json:"info"`
+}
+
+type DexScreenerResponse struct {
+	Pairs []DexScreenerPair `json:"pairs"`
+}
+
+// dexScreenerProvider only knows basic metadata (name, symbol, logo and
+// links); it has no security data.
+type dexScreenerProvider struct {
+	d *LPBurnDetector
+}
+
+func (p dexScreenerProvider) Name() string {
+	return "DexScreener"
+}
+
+func (p dexScreenerProvider) TokenDetails(ctx context.Context, address string) (*TokenDetails, error) {
+	pairs, err := p.d.getDexScreenerPairs(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pair := range pairs {
+		for _, token := range []DexScreenerToken{pair.BaseToken, pair.QuoteToken} {
+			if strings.EqualFold(token.Address, address) && token.Name != "" {
+				// The pair's info (image and links) is its base token's
+				logo := ""
+				var links []TokenLink
+				if token == pair.BaseToken {
+					logo = pair.Info.ImageURL
+					links = dexScreenerLinks(pair)
+				}
+				return &TokenDetails{
+					TokenName:   token.Name,
+					TokenSymbol: token.Symbol,
+					LogoURL:     logo,
+					Links:       links,
+				}, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no token details found: %w", ErrNotFound)
+}
+
+func (d *LPBurnDetector) getDexScreenerPairs(ctx context.Context, address string) ([]DexScreenerPair, error) {
+	reqURL := fmt.Sprintf("https://api.dexscreener.com/latest/dex/tokens/%s", address)
+
+	body, err := d.httpGet(ctx, "DexScreener", reqURL, map[string]string{"Accept": "application/json"})
+	if err != nil {
+		return nil, err
+	}
+
+	var result DexScreenerResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUpstream, err)
+	}
+
+	var pairs []DexScreenerPair
+	for _, pair := range result.Pairs {
+		if pair.ChainID == d.config.DexScreenerChain {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs, nil
+}
+
+// onChainProvider reads name and symbol from the token contract itself.
+type onChainProvider struct {
+	d *LPBurnDetector
+}
+
+func (p onChainProvider) Name() string {
+	return "on-chain"
+}
+
+func (p onChainProvider) TokenDetails(ctx context.Context, address string) (*TokenDetails, error) {
+	token := common.HexToAddress(address)
+	name, err := p.d.getTokenName(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	symbol, err := p.d.getTokenSymbol(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenDetails{TokenName: name, TokenSymbol: symbol}, nil
+}
+
+// getDetails queries GoPlus and DexScreener and merges the results: GoPlus
+// is authoritative for security data (honeypot, taxes, holders) and
+// DexScreener for name and symbol, falling back to the token contract.
+// securityProvider is nil on chains GoPlus doesn't cover.
+func (d *LPBurnDetector) getDetails(ctx context.Context, address string) (*TokenDetails, error) {
+	var security *TokenDetails
+	securityErr := fmt.Errorf("no security provider for this chain")
+	if d.securityProvider != nil {
+		if security, securityErr = d.securityProvider.TokenDetails(ctx, address); securityErr != nil {
+			logf(ctx, "Failed to get %s token details: %v", d.securityProvider.Name(), securityErr)
+		}
+	}
+
+	// Watchlist tokens are served from the prefetched metadata
+	metadata, cached := d.metadata.get(common.HexToAddress(address))
+	var metadataErr error
+	if !cached {
+		metadata, metadataErr = d.getMetadata(ctx, address)
+	}
+
+	if securityErr != nil && metadataErr != nil {
+		return nil, fmt.Errorf("all details providers failed: %w; %w", securityErr, metadataErr)
+	}
+
+	return mergeDetails(security, metadata), nil
+}
+
+// getMetadata asks the metadata provider for name, symbol and logo,
+// falling back to the token contract.
+func (d *LPBurnDetector) getMetadata(ctx context.Context, address string) (*TokenDetails, error) {
+	metadata, err := d.metadataProvider.TokenDetails(ctx, address)
+	if err != nil {
+		logf(ctx, "Failed to get %s token details: %v", d.metadataProvider.Name(), err)
+		fallback := onChainProvider{d}
+		if metadata, err = fallback.TokenDetails(ctx, address); err != nil {
+			logf(ctx, "Failed to get %s token details: %v", fallback.Name(), err)
+		}
+	}
+	return metadata, err
+}
+
+func mergeDetails(security, metadata *TokenDetails) *TokenDetails {
+	merged := TokenDetails{
+		TokenName:   "Unknown",
+		TokenSymbol: "UNK",
+		IsHoneypot:  "undefined",
+		BuyTax:      "0",
+		SellTax:     "0",
+		HolderCount: "0",
+		Holders:     []Holder{},
+	}
+
+	if security != nil {
+		merged = *security
+	}
+
+	if metadata != nil {
+		if metadata.TokenName != "" {
+			merged.TokenName = metadata.TokenName
+		}
+		if metadata.TokenSymbol != "" {
+			merged.TokenSymbol = metadata.TokenSymbol
+		}
+		merged.LogoURL = metadata.LogoURL
+		merged.Links = metadata.Links
+	}
+
+	return &merged
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+const testToken = "0x1111111111111111111111111111111111111111"
+
+// respond answers every request with status and body.
+func respond(status int, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	})
+}
+
+type providerErrorTest struct {
+	name   string
+	status int
+	body   string
+	want   error
+}
+
+var providerErrorTests = []providerErrorTest{
+	{"rate limited", http.StatusTooManyRequests, "slow down", ErrRateLimited},
+	{"not found", http.StatusNotFound, "no such token", ErrNotFound},
+	{"server error", http.StatusInternalServerError, "oops", ErrUpstream},
+	{"bad gateway", http.StatusBadGateway, "oops", ErrUpstream},
+	{"malformed", http.StatusOK, "<html>", ErrUpstream},
+}
+
+func TestGoPlusErrorClassification(t *testing.T) {
+	tests := append(providerErrorTests, providerErrorTest{"empty result", http.StatusOK, `{"code":1,"message":"OK","result":{}}`, ErrNotFound})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _ := newTestDetector(t, respond(tt.status, tt.body))
+			_, err := d.securityProvider.TokenDetails(context.Background(), testToken)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDexScreenerErrorClassification(t *testing.T) {
+	tests := append(providerErrorTests, providerErrorTest{"empty result", http.StatusOK, `{"pairs":[]}`, ErrNotFound})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _ := newTestDetector(t, respond(tt.status, tt.body))
+			_, err := d.metadataProvider.TokenDetails(context.Background(), testToken)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// With DexScreener and the token contract also failing, getDetails reports
+// GoPlus's error class.
+func TestGetDetailsErrorClassification(t *testing.T) {
+	for _, tt := range providerErrorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.Handle("/api/v1/token_security/", respond(tt.status, tt.body))
+			mux.Handle("/latest/dex/tokens/", respond(http.StatusNotFound, "no pairs"))
+			d, _ := newTestDetector(t, mux)
+
+			_, err := d.getDetails(context.Background(), testToken)
+			if err == nil {
+				t.Fatal("getDetails succeeded, want an error")
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
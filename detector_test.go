@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// fakeClock is a Clock that only moves when After is called, by the
+// duration asked for, so waits return at once and elapsed time is exact.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	now := c.now
+	c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	ch <- now
+	return ch
+}
+
+// rewriteTransport sends every request to the test server, keeping the
+// path and query, so providers with hardcoded hosts can be stubbed.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = t.target.Scheme, t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// fakeNode is a minimal JSON-RPC endpoint. Methods without a handler fail
+// the way an unreachable node would.
+type fakeNode struct {
+	mu      sync.Mutex
+	methods map[string]func(params []json.RawMessage) (interface{}, error)
+	calls   map[string]int
+}
+
+func (n *fakeNode) handle(method string, fn func(params []json.RawMessage) (interface{}, error)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.methods[method] = fn
+}
+
+func (n *fakeNode) called(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls[method]
+}
+
+// handleCall serves eth_call with fn, given the called contract and the
+// call data.
+func (n *fakeNode) handleCall(fn func(to common.Address, input []byte) ([]byte, error)) {
+	n.handle("eth_call", func(params []json.RawMessage) (interface{}, error) {
+		var call struct {
+			To    common.Address `json:"to"`
+			Input hexutil.Bytes  `json:"input"`
+			Data  hexutil.Bytes  `json:"data"`
+		}
+		if err := json.Unmarshal(params[0], &call); err != nil {
+			return nil, err
+		}
+		input := call.Input
+		if len(input) == 0 {
+			input = call.Data
+		}
+		result, err := fn(call.To, input)
+		if err != nil {
+			return nil, err
+		}
+		return hexutil.Bytes(result), nil
+	})
+}
+
+func (n *fakeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n.mu.Lock()
+	fn := n.methods[req.Method]
+	n.calls[req.Method]++
+	n.mu.Unlock()
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	err := errors.New("method not available")
+	if fn != nil {
+		var result interface{}
+		if result, err = fn(req.Params); err == nil {
+			resp["result"] = result
+		}
+	}
+	if err != nil {
+		resp["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// newTestDetector builds a detector whose node is a fakeNode and whose
+// provider requests are served by api (404 everywhere when nil). Retries
+// are off so each provider call makes one request.
+func newTestDetector(t *testing.T, api http.Handler) (*LPBurnDetector, *fakeNode) {
+	t.Helper()
+
+	node := &fakeNode{
+		methods: make(map[string]func([]json.RawMessage) (interface{}, error)),
+		calls:   make(map[string]int),
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/rpc", node)
+	if api != nil {
+		mux.Handle("/", api)
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := ethclient.Dial(server.URL + "/rpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	contractABI, err := loadContractABI("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.HTTPMaxAttempts = 1
+	httpClient := &http.Client{Transport: rewriteTransport{target}}
+	weth := common.HexToAddress(cfg.WethAddr)
+	d := &LPBurnDetector{
+		client:      client,
+		archive:     client,
+		clock:       realClock{},
+		contractABI: contractABI,
+		httpClient:  httpClient,
+		config:      cfg,
+		skips:       newSkipLogger(0, realClock{}),
+		decimals:    newDecimalsCache(),
+		metadata:    newMetadataCache(),
+		symbols:     newSymbolCache(),
+		lpSupplies:  newLPSupplyCache(),
+		proxies:     newProxyCache(),
+		messages:    &englishMessages,
+		weth:        weth,
+		work:        newWorkQueue(cfg.WorkQueueSize),
+	}
+	d.breakers = make(map[string]*CircuitBreaker)
+	for _, name := range []string{"GoPlus", "GeckoTerminal", "DexScreener", "Ethplorer"} {
+		d.breakers[name] = NewCircuitBreaker(name, cfg.BreakerThreshold, cfg.BreakerCooldown, d.clock)
+	}
+	d.quotePrices = newQuotePriceCache(httpClient, cfg.GeckoNetwork, cfg.QuotePriceTTL, []common.Address{weth}, d.breakers["GeckoTerminal"], cfg.HTTPMaxAttempts, cfg.HTTPRetryBaseDelay, realClock{})
+	d.securityProvider = goPlusProvider{d}
+	d.metadataProvider = dexScreenerProvider{d}
+	return d, node
+}
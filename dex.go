@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// knownFactories maps Ethereum mainnet factory addresses to DEX names.
+var knownFactories = map[common.Address]string{
+	common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f"): "Uniswap V2",
+	common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984"): "Uniswap V3",
+	common.HexToAddress("0xC0AEe478e3658e2610c5F7A4A2E1777cE9e4f2Ac"): "SushiSwap",
+	common.HexToAddress("0x1097053Fd2ea711dad45caCcc45EfF7548fCB362"): "PancakeSwap V2",
+	common.HexToAddress("0x0BFbCF9fa4f9C56B0F40a671Ad40E0805A091865"): "PancakeSwap V3",
+}
+
+const unknownDEX = "Unknown DEX"
+
+// dexCache remembers the DEX name resolved for each pair.
+type dexCache struct {
+	mu    sync.RWMutex
+	names map[common.Address]string
+}
+
+func newDEXCache() *dexCache {
+	return &dexCache{names: make(map[common.Address]string)}
+}
+
+func (c *dexCache) get(pair common.Address) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	name, ok := c.names[pair]
+	return name, ok
+}
+
+func (c *dexCache) set(pair common.Address, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.names[pair] = name
+}
+
+func (d *LPBurnDetector) getFactory(ctx context.Context, pairAddress common.Address) (common.Address, error) {
+	data, err := d.pairABI.Pack("factory")
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	result, err := d.client.CallContract(ctx, ethereum.CallMsg{
+		To:   &pairAddress,
+		Data: data,
+		Gas:  d.config.CallGasLimit,
+	}, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	values, err := d.pairABI.Unpack("factory", result)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(values) < 1 {
+		return common.Address{}, fmt.Errorf("unexpected factory result")
+	}
+
+	factory, ok := values[0].(common.Address)
+	if !ok {
+		return common.Address{}, fmt.Errorf("unexpected factory result type")
+	}
+	return factory, nil
+}
+
+// resolveDEX names the DEX a pair belongs to from its factory. A failed
+// call isn't cached so the next burn of the pair tries again.
+func (d *LPBurnDetector) resolveDEX(ctx context.Context, pairAddress common.Address) string {
+	if name, ok := d.dexes.get(pairAddress); ok {
+		return name
+	}
+
+	factory, err := d.getFactory(ctx, pairAddress)
+	if err != nil {
+		logf(ctx, "Failed to get factory of %s: %v", pairAddress.Hex(), err)
+		return unknownDEX
+	}
+
+	name, ok := knownFactories[factory]
+	if !ok {
+		name = unknownDEX
+	}
+	d.dexes.set(pairAddress, name)
+	return name
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// nodeDialTimeout bounds a single connection attempt.
+const nodeDialTimeout = 10 * time.Second
+
+// dialNode connects to a node, retrying with exponential backoff up to
+// NodeDialAttempts times (0 retries forever) so a node that's briefly
+// unreachable at boot doesn't kill the process. An HTTP dial never touches
+// the network, so each attempt is confirmed with an eth_chainId call.
+func dialNode(ctx context.Context, cfg Config, clock Clock, name, url string) (*ethclient.Client, error) {
+	delay := cfg.NodeDialDelay
+	for attempt := 1; ; attempt++ {
+		client, err := dialOnce(ctx, url)
+		if err == nil {
+			if attempt > 1 {
+				log.Printf("🔗 Connected to %s after %d attempts", name, attempt)
+			}
+			return client, nil
+		}
+		if cfg.NodeDialAttempts > 0 && attempt >= cfg.NodeDialAttempts {
+			return nil, fmt.Errorf("failed to connect to %s after %d attempts: %v", name, attempt, err)
+		}
+
+		attempts := "∞"
+		if cfg.NodeDialAttempts > 0 {
+			attempts = fmt.Sprint(cfg.NodeDialAttempts)
+		}
+		log.Printf("❌ Failed to connect to %s (attempt %d/%s), retrying in %s: %v", name, attempt, attempts, delay, err)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to connect to %s: %v", name, ctx.Err())
+		case <-clock.After(delay):
+		}
+		if delay *= 2; delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+func dialOnce(ctx context.Context, url string) (*ethclient.Client, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, nodeDialTimeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(dialCtx, url)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.ChainID(dialCtx); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
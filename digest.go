@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+)
+
+// alertDigest collects burn results between digests when digest mode is
+// on (AlertDigestInterval > 0); runDigest sends them as one message.
+type alertDigest struct {
+	mu      sync.Mutex
+	results []*burnResult
+}
+
+func (g *alertDigest) add(results ...*burnResult) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.results = append(g.results, results...)
+}
+
+func (g *alertDigest) drain() []*burnResult {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	results := g.results
+	g.results = nil
+	return results
+}
+
+// runDigest sends the results collected in each AlertDigestInterval as a
+// single alert, skipping intervals with none, until ctx is cancelled.
+func (d *LPBurnDetector) runDigest(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.clock.After(d.config.AlertDigestInterval):
+		}
+
+		results := d.digest.drain()
+		if len(results) == 0 {
+			continue
+		}
+
+		var mcap int64
+		for _, result := range results {
+			if result.Record.Mcap > mcap {
+				mcap = result.Record.Mcap
+			}
+		}
+		d.notify(Alert{Text: d.formatDigest(results), Mcap: mcap})
+	}
+}
+
+// formatDigest lists one line per result, dropping the rest with a count
+// once the message would no longer fit in one Telegram message.
+func (d *LPBurnDetector) formatDigest(results []*burnResult) string {
+	msg := d.messages
+	header := fmt.Sprintf("%s (%d)\n", msg.Digest, len(results))
+	footer := d.alertFooter()
+
+	var lines []string
+	for i, result := range results {
+		line := d.digestLine(result)
+		more := ""
+		if rest := len(results) - i - 1; rest > 0 {
+			more = fmt.Sprintf("\n… +%d %s", rest, msg.More)
+		}
+		text := header + strings.Join(append(lines, line), "\n") + more + footer
+		if telegramLength(text) > telegramMaxMessageLength {
+			lines = append(lines, fmt.Sprintf("… +%d %s", len(results)-i, msg.More))
+			break
+		}
+		lines = append(lines, line)
+	}
+	return header + strings.Join(lines, "\n") + footer
+}
+
+func (d *LPBurnDetector) digestLine(result *burnResult) string {
+	rec := result.Record
+	symbol := html.EscapeString(rec.TokenSymbol)
+	link := fmt.Sprintf("<a href=\"%s/tx/%s\">%s</a>", d.config.ExplorerURL, rec.TxHash, symbol)
+
+	switch result.Kind {
+	case lpAdd:
+		return fmt.Sprintf("💧 %s · $%s · %s", link, formatNumber(rec.Mcap), d.messages.Added)
+	case lpRemove, lpV3Remove:
+		return fmt.Sprintf("🚨 %s · $%s · %s", link, formatNumber(rec.Mcap), d.messages.Removed)
+	default:
+		return fmt.Sprintf("🔥 %s · $%s · %.2f%%", link, formatNumber(rec.Mcap), rec.BurnPercent)
+	}
+}
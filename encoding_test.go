@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mojibake is what UTF-8 emoji turn into when they're decoded as Mac Roman
+// and saved again: the prefixes of 🔥/🔍 and 🚀, and the whole of ❌. They're
+// spelled as escapes so this file doesn't match itself.
+var mojibake = []string{"\u00fc\u00ee", "\u00fc\u00f6", "\u201a\u00f9\u00e5"}
+
+func findMojibake(s string) string {
+	for _, seq := range mojibake {
+		if strings.Contains(s, seq) {
+			return seq
+		}
+	}
+	return ""
+}
+
+func TestSourcesHaveNoMojibake(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, line := range strings.Split(string(data), "\n") {
+			if seq := findMojibake(line); seq != "" {
+				t.Errorf("%s:%d: mojibake %q", file, i+1, seq)
+			}
+		}
+	}
+}
+
+func TestRenderedAlertHasNoMojibake(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FooterEnabled = true
+	cfg.FooterText = "🚀 Powered by burn detector"
+	d := &LPBurnDetector{config: cfg, messages: &englishMessages}
+
+	alert := d.formatStoredBurn(BurnRecord{
+		TxHash:         "0xabc",
+		TokenAddress:   "0x1111111111111111111111111111111111111111",
+		TokenName:      "Token",
+		TokenSymbol:    "TKN",
+		BurnedLP:       12.5,
+		BurnPercent:    100,
+		Mcap:           250000,
+		IsHoneypot:     "0",
+		BuyTax:         "0.01",
+		SellTax:        "0",
+		Clogged:        1000,
+		CloggedPercent: 0.1,
+		HolderCount:    42,
+		Risk:           RiskCaution,
+		RiskScore:      30,
+		RiskSignals:    []string{"owner not renounced"},
+		DetectedAt:     time.Now(),
+	})
+	if !strings.Contains(alert, "🔥") && !strings.Contains(alert, "💰") {
+		t.Fatalf("alert has no emoji to check:\n%s", alert)
+	}
+	if seq := findMojibake(alert); seq != "" {
+		t.Errorf("rendered alert contains mojibake %q:\n%s", seq, alert)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const ENS_ABI = `[
+	{
+		"constant": true,
+		"inputs": [{"name": "node", "type": "bytes32"}],
+		"name": "resolver",
+		"outputs": [{"name": "", "type": "address"}],
+		"type": "function"
+	},
+	{
+		"constant": true,
+		"inputs": [{"name": "node", "type": "bytes32"}],
+		"name": "name",
+		"outputs": [{"name": "", "type": "string"}],
+		"type": "function"
+	},
+	{
+		"constant": true,
+		"inputs": [{"name": "node", "type": "bytes32"}],
+		"name": "addr",
+		"outputs": [{"name": "", "type": "address"}],
+		"type": "function"
+	}
+]`
+
+// ensCache remembers reverse resolutions, including misses, so each address
+// costs at most one round of RPC calls.
+type ensCache struct {
+	mu    sync.RWMutex
+	names map[common.Address]string
+}
+
+func newENSCache() *ensCache {
+	return &ensCache{names: make(map[common.Address]string)}
+}
+
+func (c *ensCache) get(addr common.Address) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	name, ok := c.names[addr]
+	return name, ok
+}
+
+func (c *ensCache) set(addr common.Address, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.names[addr] = name
+}
+
+// namehash implements the ENS name hashing algorithm (EIP-137).
+func namehash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = crypto.Keccak256Hash(node.Bytes(), crypto.Keccak256([]byte(labels[i])))
+	}
+	return node
+}
+
+func (d *LPBurnDetector) callENS(ctx context.Context, contract common.Address, method string, node common.Hash) (interface{}, error) {
+	data, err := d.ensABI.Pack(method, node)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.client.CallContract(ctx, ethereum.CallMsg{
+		To:   &contract,
+		Data: data,
+		Gas:  d.config.CallGasLimit,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := d.ensABI.Unpack(method, result)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("empty %s result", method)
+	}
+	return values[0], nil
+}
+
+func (d *LPBurnDetector) resolveAddr(ctx context.Context, name string) (common.Address, error) {
+	registry := common.HexToAddress(d.config.ENSRegistry)
+	node := namehash(name)
+
+	resolver, err := d.callENS(ctx, registry, "resolver", node)
+	if err != nil {
+		return common.Address{}, err
+	}
+	resolverAddr, _ := resolver.(common.Address)
+	if resolverAddr == (common.Address{}) {
+		return common.Address{}, nil
+	}
+
+	addr, err := d.callENS(ctx, resolverAddr, "addr", node)
+	if err != nil {
+		return common.Address{}, err
+	}
+	resolved, _ := addr.(common.Address)
+	return resolved, nil
+}
+
+// lookupENS reverse-resolves addr to its primary ENS name. The name is only
+// trusted if it forward-resolves back to addr; anyone can set any reverse
+// record.
+func (d *LPBurnDetector) lookupENS(ctx context.Context, addr common.Address) (string, error) {
+	registry := common.HexToAddress(d.config.ENSRegistry)
+	node := namehash(strings.ToLower(addr.Hex()[2:]) + ".addr.reverse")
+
+	resolver, err := d.callENS(ctx, registry, "resolver", node)
+	if err != nil {
+		return "", err
+	}
+	resolverAddr, _ := resolver.(common.Address)
+	if resolverAddr == (common.Address{}) {
+		return "", nil
+	}
+
+	value, err := d.callENS(ctx, resolverAddr, "name", node)
+	if err != nil {
+		return "", err
+	}
+	name, _ := value.(string)
+	if name == "" {
+		return "", nil
+	}
+
+	forward, err := d.resolveAddr(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if forward != addr {
+		return "", nil
+	}
+	return name, nil
+}
+
+// displayAddress returns the ENS name of addr if it has one, otherwise the
+// truncated hex address.
+func (d *LPBurnDetector) displayAddress(ctx context.Context, addr common.Address) string {
+	name, ok := d.ens.get(addr)
+	if !ok {
+		var err error
+		name, err = d.lookupENS(ctx, addr)
+		if err != nil {
+			logf(ctx, "Failed to resolve ENS name for %s: %v", addr.Hex(), err)
+			return truncateAddress(addr)
+		}
+		d.ens.set(addr, name)
+	}
+
+	if name == "" {
+		return truncateAddress(addr)
+	}
+	return name
+}
+
+func truncateAddress(addr common.Address) string {
+	hex := addr.Hex()
+	return hex[:6] + "..." + hex[len(hex)-4:]
+}
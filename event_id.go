@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+)
+
+type eventIDKey struct{}
+
+// newEventID returns a short random ID for one burn event, used to tie its
+// log lines, stored record and alert together.
+func newEventID() string {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "00000000"
+	}
+	return hex.EncodeToString(b)
+}
+
+func withEventID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, eventIDKey{}, id)
+}
+
+func eventID(ctx context.Context) string {
+	id, _ := ctx.Value(eventIDKey{}).(string)
+	return id
+}
+
+// withAlertID appends the event ID in small italics to an alert, when
+// enabled, so a message in the channel can be matched to its log lines.
+func (d *LPBurnDetector) withAlertID(ctx context.Context, text string) string {
+	id := eventID(ctx)
+	if !d.config.AlertIDs || id == "" {
+		return text
+	}
+	return text + "\n<i>id: " + id + "</i>"
+}
+
+// logf logs like log.Printf, prefixed with the event ID carried by ctx.
+func logf(ctx context.Context, format string, args ...interface{}) {
+	if id := eventID(ctx); id != "" {
+		format = "[" + id + "] " + format
+	}
+	log.Printf(format, args...)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Event types selectable with WATCH_EVENTS.
+const (
+	EventDeadTransfer = "dead_transfer"
+	EventV2Burn       = "v2_burn"
+	EventV3Burn       = "v3_burn"
+)
+
+var (
+	// v2BurnTopic is topic0 of Burn(address indexed sender, uint amount0,
+	// uint amount1, address indexed to) on a Uniswap V2 pair.
+	v2BurnTopic = crypto.Keccak256Hash([]byte("Burn(address,uint256,uint256,address)"))
+
+	// v3BurnTopic is topic0 of Burn(address indexed owner, int24 indexed
+	// tickLower, int24 indexed tickUpper, uint128 amount, uint256 amount0,
+	// uint256 amount1) on a Uniswap V3 pool.
+	v3BurnTopic = crypto.Keccak256Hash([]byte("Burn(address,int24,int24,uint128,uint256,uint256)"))
+)
+
+func (d *LPBurnDetector) watching(event string) bool {
+	for _, e := range d.config.WatchEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// watchQueries returns one log filter per watched event type. The
+// topic layouts differ, so they can't share a single filter; the
+// subscriptions all feed the same channel and batcher instead.
+func (d *LPBurnDetector) watchQueries() []ethereum.FilterQuery {
+	var queries []ethereum.FilterQuery
+	if d.watching(EventDeadTransfer) {
+		queries = append(queries, burnFilterQuery(d.config.DeadAddr, d.config.FromAddrs))
+	}
+	if d.watching(EventV2Burn) {
+		queries = append(queries, ethereum.FilterQuery{Topics: [][]common.Hash{{v2BurnTopic}}})
+	}
+	if d.watching(EventV3Burn) {
+		queries = append(queries, ethereum.FilterQuery{Topics: [][]common.Hash{{v3BurnTopic}}})
+	}
+	return queries
+}
+
+// eventName describes a watched log for the scan logs.
+func eventName(vLog types.Log) string {
+	if len(vLog.Topics) == 0 {
+		return "unknown event"
+	}
+	switch vLog.Topics[0] {
+	case v2BurnTopic:
+		return "V2 liquidity removal"
+	case v3BurnTopic:
+		return "V3 liquidity removal"
+	default:
+		return "transfer to dead address"
+	}
+}
+
+// removalsFromLogs decodes the V2 and V3 Burn events among logs. A V2 Burn
+// doesn't carry the LP amount, so it is read from the pair's
+// Transfer-to-zero logs in the tx receipt.
+func (d *LPBurnDetector) removalsFromLogs(ctx context.Context, txHash common.Hash, logs []types.Log) ([]burnTransfer, error) {
+	var removals []burnTransfer
+	var receipt *types.Receipt
+	seen := make(map[uint]bool)
+	for _, vLog := range logs {
+		if len(vLog.Topics) == 0 || seen[vLog.Index] {
+			continue
+		}
+
+		switch {
+		case vLog.Topics[0] == v2BurnTopic && d.watching(EventV2Burn):
+			seen[vLog.Index] = true
+			if receipt == nil {
+				var err error
+				if receipt, err = d.client.TransactionReceipt(ctx, txHash); err != nil {
+					return nil, fmt.Errorf("failed to get receipt: %v", err)
+				}
+			}
+			burned := new(big.Int)
+			for _, l := range receipt.Logs {
+				if l.Address == vLog.Address && len(l.Topics) == 3 && l.Topics[0] == transferEventTopic &&
+					topicAddress(l.Topics[2]) == (common.Address{}) && len(l.Data) == 32 {
+					burned.Add(burned, new(big.Int).SetBytes(l.Data))
+				}
+			}
+			if burned.Sign() > 0 {
+				removals = append(removals, burnTransfer{LP: vLog.Address, Value: burned, Kind: lpRemove})
+			}
+
+		case vLog.Topics[0] == v3BurnTopic && d.watching(EventV3Burn):
+			seen[vLog.Index] = true
+			if len(vLog.Data) != 96 {
+				continue
+			}
+			// A zero-liquidity Burn only pokes the position to update fees
+			liquidity := new(big.Int).SetBytes(vLog.Data[:32])
+			if liquidity.Sign() > 0 {
+				removals = append(removals, burnTransfer{LP: vLog.Address, Value: liquidity, Kind: lpV3Remove})
+			}
+		}
+	}
+	return removals, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	HolderSourceGoPlus   = "goplus"
+	HolderSourceExplorer = "explorer"
+	HolderSourceBoth     = "both"
+)
+
+type EthplorerTokenInfo struct {
+	HoldersCount int64 `json:"holdersCount"`
+}
+
+func (d *LPBurnDetector) getExplorerHolderCount(ctx context.Context, tokenAddress common.Address) (int64, error) {
+	reqURL := fmt.Sprintf("https://api.ethplorer.io/getTokenInfo/%s?apiKey=%s", strings.ToLower(tokenAddress.Hex()), d.config.EthplorerAPIKey)
+
+	body, err := d.httpGet(ctx, "Ethplorer", reqURL, map[string]string{"Accept": "application/json"})
+	if err != nil {
+		return 0, err
+	}
+
+	var info EthplorerTokenInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return 0, err
+	}
+
+	return info.HoldersCount, nil
+}
+
+// resolveHolderCount returns the token's holder count from the configured
+// source. GoPlus reports "0" for tokens it hasn't analyzed, so zero is
+// treated as unknown and reported as ok == false.
+func (d *LPBurnDetector) resolveHolderCount(ctx context.Context, details *TokenDetails, tokenAddress common.Address) (int64, bool) {
+	var goplusCount int64
+	if d.config.HolderCountSource != HolderSourceExplorer {
+		goplusCount, _ = strconv.ParseInt(strings.TrimSpace(details.HolderCount), 10, 64)
+		if d.config.HolderCountSource == HolderSourceGoPlus {
+			return goplusCount, goplusCount > 0
+		}
+	}
+
+	explorerCount, err := d.getExplorerHolderCount(ctx, tokenAddress)
+	if err != nil {
+		logf(ctx, "Failed to get explorer holder count: %v", err)
+		return goplusCount, goplusCount > 0
+	}
+
+	if goplusCount > 0 && explorerCount > 0 && goplusCount != explorerCount {
+		logf(ctx, "Holder count mismatch for %s: GoPlus %d, explorer %d", tokenAddress.Hex(), goplusCount, explorerCount)
+	}
+
+	if goplusCount > 0 {
+		return goplusCount, true
+	}
+	return explorerCount, explorerCount > 0
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// transferEventTopic is topic0 of the ERC20 Transfer event.
+var transferEventTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// burnFilterQuery builds the log filter for transfers into the dead address.
+// Transfer(address indexed from, address indexed to, uint256 value) carries
+// the event signature in topic0, from in topic1 and to in topic2; the value
+// is not indexed and lives in the log data.
+func burnFilterQuery(deadAddr string, fromAddrs []string) ethereum.FilterQuery {
+	// Restrict the sender to the watchlist when one is configured
+	var fromTopics []common.Hash
+	for _, addr := range fromAddrs {
+		fromTopics = append(fromTopics, addressTopic(common.HexToAddress(addr)))
+	}
+
+	deadAddress := common.HexToAddress(deadAddr)
+
+	return ethereum.FilterQuery{
+		Topics: [][]common.Hash{
+			{transferEventTopic},
+			fromTopics,                  // from (any address when empty)
+			{addressTopic(deadAddress)}, // to (dead address)
+		},
+	}
+}
+
+// addressTopic encodes an address the way the EVM stores an indexed address
+// parameter: the 20 address bytes right-aligned in a 32-byte word with 12
+// leading zero bytes. Getting this wrong makes the subscription silently
+// match nothing, so the padding is done explicitly rather than relying on
+// BytesToHash's truncation rules.
+func addressTopic(addr common.Address) common.Hash {
+	return common.BytesToHash(common.LeftPadBytes(addr.Bytes(), common.HashLength))
+}
+
+// topicAddress is the inverse of addressTopic.
+func topicAddress(topic common.Hash) common.Address {
+	return common.BytesToAddress(topic.Bytes()[common.HashLength-common.AddressLength:])
+}
+
+// mintEventTopic is topic0 of the Uniswap V2 pair Mint event, emitted when
+// liquidity is added.
+var mintEventTopic = crypto.Keccak256Hash([]byte("Mint(address,uint256,uint256)"))
+
+// mintFilterQuery builds the log filter for liquidity additions to V2 pairs.
+func mintFilterQuery() ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Topics: [][]common.Hash{{mintEventTopic}},
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestBurnFilterQueryTopics(t *testing.T) {
+	dead := "0x000000000000000000000000000000000000dEaD"
+	from := "0x1111111111111111111111111111111111111111"
+
+	q := burnFilterQuery(dead, []string{from})
+	if len(q.Topics) != 3 {
+		t.Fatalf("got %d topic positions, want 3", len(q.Topics))
+	}
+
+	wantSig := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	if len(q.Topics[0]) != 1 || q.Topics[0][0] != wantSig {
+		t.Errorf("topic0 = %v, want [%v]", q.Topics[0], wantSig)
+	}
+	if len(q.Topics[1]) != 1 || q.Topics[1][0] != common.BytesToHash(common.HexToAddress(from).Bytes()) {
+		t.Errorf("topic1 = %v, want the watched sender", q.Topics[1])
+	}
+	if len(q.Topics[2]) != 1 || q.Topics[2][0] != common.BytesToHash(common.HexToAddress(dead).Bytes()) {
+		t.Errorf("topic2 = %v, want the dead address", q.Topics[2])
+	}
+
+	// Without a watchlist any sender matches, but the positions stay fixed.
+	q = burnFilterQuery(dead, nil)
+	if len(q.Topics) != 3 || q.Topics[1] != nil {
+		t.Errorf("topic1 = %v, want a wildcard", q.Topics[1])
+	}
+	if q.Topics[2][0] != common.BytesToHash(common.HexToAddress(dead).Bytes()) {
+		t.Errorf("topic2 = %v, want the dead address", q.Topics[2])
+	}
+}
+
+func TestAddressTopic(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{
+			addr: "0x000000000000000000000000000000000000dEaD",
+			want: "0x000000000000000000000000000000000000000000000000000000000000dead",
+		},
+		{
+			addr: "0x0000000000000000000000000000000000000000",
+			want: "0x0000000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+	for _, tt := range tests {
+		addr := common.HexToAddress(tt.addr)
+		want := common.HexToHash(tt.want)
+		if got := common.BytesToHash(addr.Bytes()); got != want {
+			t.Errorf("BytesToHash(%s) = %s, want %s", tt.addr, got.Hex(), tt.want)
+		}
+		if got := addressTopic(addr); got != want {
+			t.Errorf("addressTopic(%s) = %s, want %s", tt.addr, got.Hex(), tt.want)
+		}
+		if got := topicAddress(want); got != addr {
+			t.Errorf("topicAddress(%s) = %s, want %s", tt.want, got.Hex(), tt.addr)
+		}
+	}
+}
+
+func TestMinBurnFilter(t *testing.T) {
+	d, node := newTestDetector(t, nil)
+	d.config.MinBurnPercent = 1
+	d.config.MinBurnRaw = big.NewInt(1000)
+
+	lp := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	node.handleCall(func(to common.Address, input []byte) ([]byte, error) {
+		return common.LeftPadBytes(big.NewInt(1_000_000).Bytes(), 32), nil // totalSupply
+	})
+
+	burn := func(value int64) types.Log {
+		return types.Log{
+			Address: lp,
+			Topics: []common.Hash{
+				transferEventTopic,
+				addressTopic(common.HexToAddress(testToken)),
+				addressTopic(common.HexToAddress("0x000000000000000000000000000000000000dEaD")),
+			},
+			Data: common.LeftPadBytes(big.NewInt(value).Bytes(), 32),
+		}
+	}
+
+	// Dust is rejected as it arrives, without touching the node
+	if d.admitLog(burn(1000)) {
+		t.Error("admitLog accepted a burn at MinBurnRaw")
+	}
+	if !d.admitLog(burn(5000)) {
+		t.Error("admitLog rejected a burn above MinBurnRaw")
+	}
+	if node.called("eth_call") > 0 {
+		t.Error("admitLog read the LP supply")
+	}
+
+	kept := d.filterMinBurn(context.Background(), []types.Log{burn(5000), burn(500_000)})
+	if len(kept) != 1 || new(big.Int).SetBytes(kept[0].Data).Int64() != 500_000 {
+		t.Errorf("kept %d logs, want only the 50%% burn", len(kept))
+	}
+}
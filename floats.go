@@ -0,0 +1,22 @@
+package main
+
+import "math/big"
+
+// floatPrec is the mantissa precision, in bits, of the big.Float values in
+// supply, price and percentage math. A zero-precision big.Float takes its
+// precision from its operands, so results could vary with the size of the
+// numbers involved; a fixed precision keeps figures reproducible.
+// NewLPBurnDetector sets it from FloatPrecision.
+var floatPrec uint = 128
+
+func newFloat() *big.Float {
+	return new(big.Float).SetPrec(floatPrec)
+}
+
+func floatFromInt(x *big.Int) *big.Float {
+	return newFloat().SetInt(x)
+}
+
+func floatFrom(x float64) *big.Float {
+	return newFloat().SetFloat64(x)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestFloatPrecision(t *testing.T) {
+	if got := newFloat().Prec(); got != floatPrec {
+		t.Errorf("newFloat().Prec() = %d, want %d", got, floatPrec)
+	}
+
+	// 2^100+1 needs 101 bits; a float64 would drop the trailing 1
+	x := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 100), big.NewInt(1))
+	if got := floatFromInt(x).Text('f', 0); got != "1267650600228229401496703205377" {
+		t.Errorf("floatFromInt(2^100+1) = %s", got)
+	}
+}
+
+func TestLPBurnSharePrecision(t *testing.T) {
+	value, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	supply, _ := new(big.Int).SetString("370370367037037036703703703670", 10)
+
+	burned, percent := lpBurnShare(value, supply, 18)
+	if got := burned.Text('f', 18); got != "123456789012.345678901234567890" {
+		t.Errorf("burned = %s", got)
+	}
+	if got := percent.Text('f', 20); got != "33.33333333333333333333" {
+		t.Errorf("percent = %s", got)
+	}
+}
+
+func TestV3Price(t *testing.T) {
+	// A USDC (6 decimals) / WETH (18 decimals) pool at 2500 USDC per WETH:
+	// 4e8 raw WETH per raw USDC, so sqrtPriceX96 = 20000 * 2^96
+	sqrtPriceX96 := new(big.Int).Lsh(big.NewInt(20000), 96)
+
+	wethPrice := v3Price(sqrtPriceX96, 6, 18, false)
+	if got := wethPrice.Text('f', 10); got != "2500.0000000000" {
+		t.Errorf("WETH price = %s USDC, want 2500", got)
+	}
+	if got := wethPrice.Prec(); got != floatPrec {
+		t.Errorf("precision = %d, want %d", got, floatPrec)
+	}
+	if got := v3Price(sqrtPriceX96, 6, 18, true).Text('g', 10); got != "0.0004" {
+		t.Errorf("USDC price = %s WETH, want 0.0004", got)
+	}
+}
+
+func TestVerboseGasPrice(t *testing.T) {
+	tests := []struct {
+		wei  int64
+		want string
+	}{
+		{1_000_000_000, "1.00 gwei"},
+		{1_234_567_890_123, "1234.57 gwei"},
+		{15_500_000, "0.02 gwei"},
+	}
+	for _, tt := range tests {
+		d, _ := newTestDetector(t, nil)
+		to := common.HexToAddress(testToken)
+		tx := types.NewTx(&types.LegacyTx{To: &to, Gas: 21000, GasPrice: big.NewInt(tt.wei)})
+
+		section := d.formatVerboseSection(context.Background(), tx, big.NewInt(1), to, to)
+		if !strings.Contains(section, tt.want) {
+			t.Errorf("gas price %d wei: want %q in\n%s", tt.wei, tt.want, section)
+		}
+	}
+}
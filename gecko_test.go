@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// geckoFixture is a trimmed GeckoTerminal pool response. SWAP_COUNT is
+// replaced by a number or a string, the two variants the API returns.
+const geckoFixture = `{
+	"data": {
+		"id": "eth_0x2222222222222222222222222222222222222222",
+		"attributes": {
+			"base_price_in_usd": "0.00012",
+			"base_address": "0x1111111111111111111111111111111111111111",
+			"swap_count": SWAP_COUNT,
+			"base_price_in_usd_percent_change": "12.5",
+			"reserve_in_usd": "41000.5",
+			"price_change_data": {
+				"last_300_s": {"base_token_usd": "1.2"},
+				"last_86400_s": {"prices": {"base_token_high_price_in_usd": "0.0002", "base_token_low_price_in_usd": "0.0001"}}
+			}
+		}
+	},
+	"included": [
+		{
+			"type": "pair",
+			"attributes": {
+				"base_price_in_usd": "0.00012",
+				"base_address": "0x1111111111111111111111111111111111111111",
+				"swap_count": SWAP_COUNT,
+				"reserve_in_usd": "41000.5"
+			}
+		}
+	]
+}`
+
+func TestGeckoResponseSwapCountVariants(t *testing.T) {
+	var numeric, text GeckoResponse
+	if err := json.Unmarshal([]byte(strings.ReplaceAll(geckoFixture, "SWAP_COUNT", `1234`)), &numeric); err != nil {
+		t.Fatalf("numeric swap_count: %v", err)
+	}
+	if err := json.Unmarshal([]byte(strings.ReplaceAll(geckoFixture, "SWAP_COUNT", `"1234"`)), &text); err != nil {
+		t.Fatalf("string swap_count: %v", err)
+	}
+
+	if !reflect.DeepEqual(numeric, text) {
+		t.Errorf("variants decode differently:\nnumeric: %+v\nstring:  %+v", numeric, text)
+	}
+	if numeric.Data.Attributes.SwapCount != 1234 {
+		t.Errorf("data swap_count = %d, want 1234", numeric.Data.Attributes.SwapCount)
+	}
+	if len(numeric.Included) != 1 || numeric.Included[0].Attributes.SwapCount != 1234 {
+		t.Errorf("included = %+v, want one pair with swap_count 1234", numeric.Included)
+	}
+	if got := numeric.Data.Attributes.PriceChangeData.Last86400s.Prices.BaseTokenHighPriceInUsd; got != "0.0002" {
+		t.Errorf("24h high = %q, want 0.0002", got)
+	}
+
+	// getPriceData decodes leniently; that must agree too
+	var lenient GeckoResponse
+	if err := decodeLenient(context.Background(), "GeckoTerminal", []byte(strings.ReplaceAll(geckoFixture, "SWAP_COUNT", `"1234"`)), &lenient); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(numeric, lenient) {
+		t.Errorf("lenient decode differs:\nwant: %+v\ngot:  %+v", numeric, lenient)
+	}
+}
+
+func TestFlexInt(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    flexInt
+		wantErr bool
+	}{
+		{`12`, 12, false},
+		{`"12"`, 12, false},
+		{`""`, 0, false},
+		{`null`, 0, false},
+		{`"twelve"`, 0, true},
+	}
+	for _, tt := range tests {
+		n := flexInt(-1)
+		err := json.Unmarshal([]byte(tt.in), &n)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) err = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && n != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, want %d", tt.in, n, tt.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ go 1.24.4
 require (
 	github.com/ethereum/go-ethereum v1.16.1
 	github.com/go-telegram-bot-api/telegram-bot-api/v5 v5.5.1
+	golang.org/x/sync v0.12.0
 )
 
 require (
@@ -28,6 +29,5 @@ require (
 	github.com/tklauser/go-sysconf v0.3.12 // indirect
 	github.com/tklauser/numcpus v0.6.1 // indirect
 	golang.org/x/crypto v0.36.0 // indirect
-	golang.org/x/sync v0.12.0 // indirect
 	golang.org/x/sys v0.31.0 // indirect
 )
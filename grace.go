@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// partialBurn is a sub-100% burn held for BurnGraceWindow in case further
+// burns of the same LP complete it. value is the LP burned so far and
+// result the analysis of that total as of the latest burn.
+type partialBurn struct {
+	value       *big.Int
+	txHash      common.Hash
+	blockNumber *big.Int
+	result      *burnResult
+	eventID     string
+
+	// first is the entry this one was combined from, or itself; the
+	// grace timer started for it only releases entries of its chain.
+	first *partialBurn
+}
+
+type partialBurns struct {
+	mu      sync.Mutex
+	pending map[common.Address]*partialBurn
+}
+
+// holdPartialBurns takes the burns below FullBurnPercent out of results and
+// holds them. A burn of an LP already held is added to it and the total
+// re-analyzed; if that reaches FullBurnPercent it's returned to be alerted
+// as one full burn, otherwise it's held on. Removals and adds pass through.
+func (d *LPBurnDetector) holdPartialBurns(ctx context.Context, tx *types.Transaction, blockNumber *big.Int, results []*burnResult) []*burnResult {
+	var send []*burnResult
+	for _, result := range results {
+		if result.Kind != lpBurn {
+			send = append(send, result)
+			continue
+		}
+
+		lp := common.HexToAddress(result.Record.LPAddress)
+		value := result.value
+		d.partials.mu.Lock()
+		held := d.partials.pending[lp]
+		d.partials.mu.Unlock()
+
+		if held != nil {
+			total := new(big.Int).Add(held.value, value)
+			combined, err := d.analyzeLPBurn(ctx, tx, lp, total, blockNumber, lpBurn)
+			if err != nil {
+				logf(ctx, "Failed to combine burn of %s with the held one, keeping them apart: %v", lp.Hex(), err)
+				d.releasePartial(lp, held)
+			} else {
+				result, value = combined, total
+			}
+		}
+
+		if result.Record.BurnPercent >= d.config.FullBurnPercent {
+			if held != nil {
+				d.partials.mu.Lock()
+				if d.partials.pending[lp] == held {
+					delete(d.partials.pending, lp)
+				}
+				d.partials.mu.Unlock()
+				logf(ctx, "🔥 Burns of %s completed within the grace window", lp.Hex())
+			}
+			send = append(send, result)
+			continue
+		}
+
+		p := &partialBurn{
+			value:       value,
+			txHash:      tx.Hash(),
+			blockNumber: blockNumber,
+			result:      result,
+			eventID:     eventID(ctx),
+		}
+		d.holdPartial(ctx, lp, p, held)
+	}
+	return send
+}
+
+// holdPartial stores p for lp, replacing prev (the entry it was combined
+// from), and alerts on it once BurnGraceWindow has passed since the first
+// burn was held.
+func (d *LPBurnDetector) holdPartial(ctx context.Context, lp common.Address, p *partialBurn, prev *partialBurn) {
+	d.partials.mu.Lock()
+	defer d.partials.mu.Unlock()
+
+	if prev != nil && d.partials.pending[lp] == prev {
+		p.first = prev.first
+		d.partials.pending[lp] = p
+		logf(ctx, "⏳ Still holding partial burn of %s (%.2f%%)", lp.Hex(), p.result.Record.BurnPercent)
+		return
+	}
+
+	p.first = p
+	d.partials.pending[lp] = p
+	logf(ctx, "⏳ Holding partial burn of %s (%.2f%%) for %s", lp.Hex(), p.result.Record.BurnPercent, d.config.BurnGraceWindow)
+	go func() {
+		<-d.clock.After(d.config.BurnGraceWindow)
+		d.partials.mu.Lock()
+		current := d.partials.pending[lp]
+		d.partials.mu.Unlock()
+		if current != nil && current.first == p {
+			d.releasePartial(lp, current)
+		}
+	}()
+}
+
+// releasePartial alerts on a held burn with the figure reached, unless it
+// has already been released or completed.
+func (d *LPBurnDetector) releasePartial(lp common.Address, p *partialBurn) {
+	d.partials.mu.Lock()
+	if d.partials.pending[lp] != p {
+		d.partials.mu.Unlock()
+		return
+	}
+	delete(d.partials.pending, lp)
+	d.partials.mu.Unlock()
+
+	ctx := withEventID(context.Background(), p.eventID)
+	logf(ctx, "⏳ Grace window over for %s, alerting partial burn (%.2f%%)", lp.Hex(), p.result.Record.BurnPercent)
+	d.alertResults(ctx, p.txHash, p.blockNumber, []*burnResult{p.result})
+}
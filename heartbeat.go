@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// detectorStats counts activity since the last heartbeat. Counters are reset
+// each time a heartbeat is logged.
+type detectorStats struct {
+	blocks    atomic.Int64
+	transfers atomic.Int64
+	burns     atomic.Int64
+	skips     atomic.Int64
+	apiErrors atomic.Int64
+	connected atomic.Bool
+
+	// head is the latest block from the new-heads subscription, seen at
+	// headAt (Unix nanoseconds). Unlike the counters they're never reset.
+	head   atomic.Uint64
+	headAt atomic.Int64
+}
+
+func (s *detectorStats) setHead(block uint64, now time.Time) {
+	s.head.Store(block)
+	s.headAt.Store(now.UnixNano())
+}
+
+// chainHead returns the latest block seen and how long before now; ok is
+// false before the first head arrives.
+func (s *detectorStats) chainHead(now time.Time) (block uint64, age time.Duration, ok bool) {
+	at := s.headAt.Load()
+	if at == 0 {
+		return 0, 0, false
+	}
+	return s.head.Load(), now.Sub(time.Unix(0, at)), true
+}
+
+// runHeartbeat logs a throughput summary once per interval so operators can
+// see the detector is alive.
+func (d *LPBurnDetector) runHeartbeat(interval time.Duration) {
+	for {
+		<-d.clock.After(interval)
+
+		status := "connected"
+		if !d.stats.connected.Load() {
+			status = "disconnected"
+		}
+		head := "unknown"
+		if block, age, ok := d.stats.chainHead(d.clock.Now()); ok {
+			head = fmt.Sprintf("%d (%s ago)", block, age.Round(time.Second))
+		}
+		log.Printf("💓 Heartbeat (%s): %d block(s), %d transfer(s), %d burn(s), %d skip(s), %d API error(s), node %s, head %s",
+			interval,
+			d.stats.blocks.Swap(0),
+			d.stats.transfers.Swap(0),
+			d.stats.burns.Swap(0),
+			d.stats.skips.Swap(0),
+			d.stats.apiErrors.Swap(0),
+			status,
+			head,
+		)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// followUpPrice polls GeckoTerminal for a burn alerted with no market cap and
+// edits the Telegram message once a price shows up, giving up after
+// LatePriceWindow.
+func (d *LPBurnDetector) followUpPrice(ctx context.Context, result *burnResult, msg TelegramMessage, text string) {
+	deadline := d.clock.Now().Add(d.config.LatePriceWindow)
+
+	for {
+		<-d.clock.After(d.config.LatePriceInterval)
+		if d.clock.Now().After(deadline) {
+			logf(ctx, "No price for %s within %s, leaving alert as sent", result.Record.TokenAddress, d.config.LatePriceWindow)
+			return
+		}
+
+		fetchCtx, cancel := context.WithTimeout(context.Background(), d.config.ProcessTimeout)
+		priceData, err := d.getPriceData(fetchCtx, result.Record.LPAddress, result.BaseToken)
+		if err != nil || priceData.Mcap <= 0 {
+			cancel()
+			continue
+		}
+
+		// The alert template renders a market cap no source knew as $0
+		unknownMcapLine := fmt.Sprintf("<b>%s:</b> $0\n", d.messages.Mcap)
+		updated := strings.Replace(text, unknownMcapLine, fmt.Sprintf("<b>%s:</b> $%s (%s)\n", d.messages.Mcap, formatNumber(priceData.Mcap), d.messages.Updated), 1)
+		err = d.telegram.editTelegramMessage(fetchCtx, msg, updated)
+		cancel()
+		if err != nil {
+			logf(ctx, "❌ Failed to edit alert with late price: %v", err)
+		} else {
+			logf(ctx, "✏️ Updated alert for %s with late mcap $%s", result.Record.TokenAddress, formatNumber(priceData.Mcap))
+		}
+		return
+	}
+}
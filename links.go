@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+)
+
+// TokenLink is one of a project's website or social links.
+type TokenLink struct {
+	Label string
+	URL   string
+}
+
+// safeLinkURL returns raw normalized if it's an absolute http(s) URL, so
+// provider data can't smuggle javascript: or other schemes into an alert.
+func safeLinkURL(raw string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", false
+	}
+	return u.String(), true
+}
+
+// dexScreenerLinks collects the pair's website and social links, dropping
+// unsafe or duplicate URLs.
+func dexScreenerLinks(pair DexScreenerPair) []TokenLink {
+	var links []TokenLink
+	seen := make(map[string]bool)
+	add := func(label, raw string) {
+		link, ok := safeLinkURL(raw)
+		if !ok || seen[link] {
+			return
+		}
+		seen[link] = true
+		if label == "" {
+			label = "Link"
+		}
+		links = append(links, TokenLink{Label: label, URL: link})
+	}
+
+	for _, website := range pair.Info.Websites {
+		label := website.Label
+		if label == "" {
+			label = "Website"
+		}
+		add(label, website.URL)
+	}
+	for _, social := range pair.Info.Socials {
+		label := social.Type
+		if label != "" {
+			label = strings.ToUpper(label[:1]) + label[1:]
+		}
+		add(label, social.URL)
+	}
+	return links
+}
+
+// formatLinks renders links as an alert line, or "" when there are none.
+func formatLinks(label string, links []TokenLink) string {
+	if len(links) == 0 {
+		return ""
+	}
+	anchors := make([]string, len(links))
+	for i, link := range links {
+		anchors[i] = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(link.URL), html.EscapeString(link.Label))
+	}
+	return fmt.Sprintf("\n🔗 <b>%s:</b> %s", label, strings.Join(anchors, " | "))
+}
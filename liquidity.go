@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// processLiquidityAdd alerts on the pair Mint event vLog. The minted LP
+// amount comes from the pair's Transfer-from-zero logs in the same tx.
+func (d *LPBurnDetector) processLiquidityAdd(ctx context.Context, vLog types.Log) error {
+	tx, err := d.getMinedTransaction(ctx, vLog.TxHash)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := d.client.TransactionReceipt(ctx, vLog.TxHash)
+	if err != nil {
+		return fmt.Errorf("failed to get receipt: %v", err)
+	}
+
+	// The first mint of a pair also locks MINIMUM_LIQUIDITY to the zero
+	// address, which isn't part of what the provider added.
+	minted := new(big.Int)
+	for _, l := range receipt.Logs {
+		if l.Address != vLog.Address || len(l.Topics) != 3 || l.Topics[0] != transferEventTopic || len(l.Data) != 32 {
+			continue
+		}
+		if topicAddress(l.Topics[1]) != (common.Address{}) || topicAddress(l.Topics[2]) == (common.Address{}) {
+			continue
+		}
+		minted.Add(minted, new(big.Int).SetBytes(l.Data))
+	}
+	if minted.Sign() == 0 {
+		return skipf("no LP tokens minted by %s in tx", vLog.Address.Hex())
+	}
+
+	blockNumber := new(big.Int).SetUint64(vLog.BlockNumber)
+	result, err := d.analyzeLPBurn(ctx, tx, vLog.Address, minted, blockNumber, lpAdd)
+	if err != nil {
+		return err
+	}
+
+	if d.config.DryRun {
+		return nil
+	}
+	if d.digest != nil {
+		d.digest.add(result)
+		return nil
+	}
+	alert := Alert{Text: d.withAlertID(ctx, result.Message), Mcap: result.Record.Mcap, Thread: strings.ToLower(result.Record.LPAddress)}
+	if d.config.AlertLogos {
+		alert.PhotoURL = result.LogoURL
+	}
+	d.notify(alert)
+	return nil
+}
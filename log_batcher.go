@@ -0,0 +1,52 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// logBatcher groups logs by transaction. The first log of a tx starts a
+// flush timer; every log of that tx seen before it fires is delivered
+// together on out, so a tx burning several LP tokens is processed once.
+type logBatcher struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending map[common.Hash][]types.Log
+	out     chan []types.Log
+	clock   Clock
+}
+
+func newLogBatcher(window time.Duration, clock Clock) *logBatcher {
+	return &logBatcher{
+		window:  window,
+		pending: make(map[common.Hash][]types.Log),
+		out:     make(chan []types.Log),
+		clock:   clock,
+	}
+}
+
+func (b *logBatcher) add(vLog types.Log) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.pending[vLog.TxHash]; !ok {
+		txHash := vLog.TxHash
+		go func() {
+			<-b.clock.After(b.window)
+			b.flush(txHash)
+		}()
+	}
+	b.pending[vLog.TxHash] = append(b.pending[vLog.TxHash], vLog)
+}
+
+func (b *logBatcher) flush(txHash common.Hash) {
+	b.mu.Lock()
+	batch := b.pending[txHash]
+	delete(b.pending, txHash)
+	b.mu.Unlock()
+
+	b.out <- batch
+}
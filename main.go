@@ -2,24 +2,29 @@ package main
 
 import (
 	"context"
-	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"io"
+	"html"
 	"log"
 	"math/big"
 	"net/http"
-	"net/url"
+	"os"
+	"os/signal"
+	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"golang.org/x/sync/errgroup"
 )
 
 // Configuration constants
@@ -87,6 +92,16 @@ const ERC20_ABI = `[
 		"name": "transfer",
 		"outputs": [{"name": "", "type": "bool"}],
 		"type": "function"
+	},
+	{
+		"constant": false,
+		"inputs": [
+			{"name": "_spender", "type": "address"},
+			{"name": "_value", "type": "uint256"}
+		],
+		"name": "approve",
+		"outputs": [{"name": "", "type": "bool"}],
+		"type": "function"
 	}
 ]`
 
@@ -99,6 +114,20 @@ type TokenDetails struct {
 	SellTax     string   `json:"sell_tax"`
 	HolderCount string   `json:"holder_count"`
 	Holders     []Holder `json:"holders"`
+
+	// "1" or "0"; empty when GoPlus didn't report them
+	IsOpenSource string `json:"is_open_source"`
+	IsProxy      string `json:"is_proxy"`
+
+	// OwnerAddress is empty when GoPlus found no owner function
+	OwnerAddress string `json:"owner_address"`
+
+	LogoURL string      `json:"-"`
+	Links   []TokenLink `json:"-"`
+
+	// LP holders of the token's main pool, as reported by GoPlus
+	LPHolderCount string   `json:"lp_holder_count"`
+	LPHolders     []Holder `json:"lp_holders"`
 }
 
 type Holder struct {
@@ -116,153 +145,334 @@ type PriceData struct {
 		Last15 string `json:"last_15"`
 		Last5  string `json:"last_5"`
 	} `json:"price_change"`
-	HighestPrice string `json:"highest_price"`
-	LowestPrice  string `json:"lowest_price"`
+	HighestPrice string  `json:"highest_price"`
+	LowestPrice  string  `json:"lowest_price"`
+	LiquidityUSD float64 `json:"liquidity_usd"`
+
+	// Source is the price source that produced this data
+	Source string `json:"source"`
+}
+
+// GeckoPoolAttributes is the pool payload GeckoTerminal returns both as the
+// top-level data and for each included pair.
+type GeckoPoolAttributes struct {
+	BasePriceInUsd              string  `json:"base_price_in_usd"`
+	BaseAddress                 string  `json:"base_address"`
+	SwapCount                   flexInt `json:"swap_count"`
+	BasePriceInUsdPercentChange string  `json:"base_price_in_usd_percent_change"`
+	ReserveInUsd                string  `json:"reserve_in_usd"`
+	PriceChangeData             struct {
+		Last300s struct {
+			BaseTokenUsd string `json:"base_token_usd"`
+		} `json:"last_300_s"`
+		Last900s struct {
+			BaseTokenUsd string `json:"base_token_usd"`
+		} `json:"last_900_s"`
+		Last1800s struct {
+			BaseTokenUsd string `json:"base_token_usd"`
+		} `json:"last_1800_s"`
+		Last86400s struct {
+			Prices struct {
+				BaseTokenHighPriceInUsd string `json:"base_token_high_price_in_usd"`
+				BaseTokenLowPriceInUsd  string `json:"base_token_low_price_in_usd"`
+			} `json:"prices"`
+		} `json:"last_86400_s"`
+	} `json:"price_change_data"`
 }
 
-type GeckoTerminalResponse struct {
+type GeckoResponse struct {
 	Data struct {
-		Attributes struct {
-			BasePriceInUsd              string `json:"base_price_in_usd"`
-			BaseAddress                 string `json:"base_address"`
-			SwapCount                   int    `json:"swap_count"`
-			BasePriceInUsdPercentChange string `json:"base_price_in_usd_percent_change"`
-			PriceChangeData             struct {
-				Last300s struct {
-					BaseTokenUsd string `json:"base_token_usd"`
-				} `json:"last_300_s"`
-				Last900s struct {
-					BaseTokenUsd string `json:"base_token_usd"`
-				} `json:"last_900_s"`
-				Last1800s struct {
-					BaseTokenUsd string `json:"base_token_usd"`
-				} `json:"last_1800_s"`
-				Last86400s struct {
-					Prices struct {
-						BaseTokenHighPriceInUsd string `json:"base_token_high_price_in_usd"`
-						BaseTokenLowPriceInUsd  string `json:"base_token_low_price_in_usd"`
-					} `json:"prices"`
-				} `json:"last_86400_s"`
-			} `json:"price_change_data"`
-		} `json:"attributes"`
+		Attributes GeckoPoolAttributes `json:"attributes"`
 	} `json:"data"`
 	Included []struct {
-		Attributes struct {
-			BasePriceInUsd              string `json:"base_price_in_usd"`
-			BaseAddress                 string `json:"base_address"`
-			SwapCount                   int    `json:"swap_count"`
-			BasePriceInUsdPercentChange string `json:"base_price_in_usd_percent_change"`
-			PriceChangeData             struct {
-				Last300s struct {
-					BaseTokenUsd string `json:"base_token_usd"`
-				} `json:"last_300_s"`
-				Last900s struct {
-					BaseTokenUsd string `json:"base_token_usd"`
-				} `json:"last_900_s"`
-				Last1800s struct {
-					BaseTokenUsd string `json:"base_token_usd"`
-				} `json:"last_1800_s"`
-				Last86400s struct {
-					Prices struct {
-						BaseTokenHighPriceInUsd string `json:"base_token_high_price_in_usd"`
-						BaseTokenLowPriceInUsd  string `json:"base_token_low_price_in_usd"`
-					} `json:"prices"`
-				} `json:"last_86400_s"`
-			} `json:"price_change_data"`
-		} `json:"attributes"`
+		Attributes GeckoPoolAttributes `json:"attributes"`
 	} `json:"included"`
 }
 
+// flexInt decodes an integer that GeckoTerminal sometimes sends as a JSON
+// number and sometimes as a string. null and "" decode as 0.
+type flexInt int64
+
+func (n *flexInt) UnmarshalJSON(data []byte) error {
+	raw := strings.Trim(string(data), `"`)
+	if raw == "" || raw == "null" {
+		*n = 0
+		return nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s: %v", string(data), err)
+	}
+	*n = flexInt(v)
+	return nil
+}
+
 type GoPlusResponse struct {
 	Result map[string]TokenDetails `json:"result"`
 }
 
 type LPBurnDetector struct {
-	client      *ethclient.Client
-	contractABI abi.ABI
-	httpClient  *http.Client
+	client       *ethclient.Client
+	archive      *ethclient.Client
+	clock        Clock
+	contractABI  abi.ABI
+	routerABI    abi.ABI
+	pairABI      abi.ABI
+	v3PoolABI    abi.ABI
+	chainlinkABI abi.ABI
+	ensABI       abi.ABI
+	httpClient   *http.Client
+	config       Config
+	telegram     *TelegramNotifier
+	slack        *SlackNotifier
+	webhook      *WebhookNotifier
+	notifiers    []Notifier
+	quotePrices  *quotePriceCache
+	store        *BurnStore
+	breakers     map[string]*CircuitBreaker
+	skips        *skipLogger
+	stats        detectorStats
+	ens          *ensCache
+	decimals     *decimalsCache
+	metadata     *metadataCache
+	symbols      *symbolCache
+	lpSupplies   *lpSupplyCache
+	dexes        *dexCache
+	proxies      *proxyCache
+	messages     *Messages
+
+	weth             common.Address
+	fromAddrs        AddressSet
+	supplyWatchPairs AddressSet
+	supplyWatch      supplyWatch
+	stableAddrs      AddressSet
+	projectTokens    AddressSet
+	cursor           *BlockCursor
+	reconnects       reconnectTracker
+	resync           resyncGuard
+	priceSources     priceSourceCounts
+	canary           canaryState
+	processed        *ProcessedTxs
+	digest           *alertDigest
+	partials         partialBurns
+	work             *workQueue
+	mode             atomic.Value // subscription mode in use, ws or poll
+
+	// securityProvider supplies honeypot/tax/holder data and
+	// metadataProvider supplies name and symbol; see getDetails.
+	securityProvider DetailsProvider
+	metadataProvider DetailsProvider
 }
 
-func NewLPBurnDetector() (*LPBurnDetector, error) {
-	client, err := ethclient.Dial(NODE_URL)
+// NewLPBurnDetector connects to the node and builds the detector, waiting
+// for the node to come up until ctx is cancelled. clock is normally
+// realClock{}; a nil clock means the same.
+func NewLPBurnDetector(ctx context.Context, cfg Config, clock Clock) (*LPBurnDetector, error) {
+	if clock == nil {
+		clock = realClock{}
+	}
+	floatPrec = uint(cfg.FloatPrecision)
+
+	client, err := dialNode(ctx, cfg, clock, "Ethereum client", NODE_URL)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := client
+	if cfg.ArchiveNodeURL != "" {
+		if archive, err = dialNode(ctx, cfg, clock, "archive node", cfg.ArchiveNodeURL); err != nil {
+			return nil, err
+		}
+	}
+
+	contractABI, err := loadContractABI(cfg.ExtraABIFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLPExtraViews(contractABI, cfg.LPExtraViews); err != nil {
+		return nil, err
+	}
+
+	routerABI, err := abi.JSON(strings.NewReader(ROUTER_ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse router ABI: %v", err)
+	}
+
+	pairABI, err := abi.JSON(strings.NewReader(PAIR_ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pair ABI: %v", err)
+	}
+
+	v3PoolABI, err := abi.JSON(strings.NewReader(V3_POOL_ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse V3 pool ABI: %v", err)
+	}
+
+	chainlinkABI, err := abi.JSON(strings.NewReader(CHAINLINK_ABI))
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Ethereum client: %v", err)
+		return nil, fmt.Errorf("failed to parse oracle ABI: %v", err)
 	}
 
-	contractABI, err := abi.JSON(strings.NewReader(ERC20_ABI))
+	ensABI, err := abi.JSON(strings.NewReader(ENS_ABI))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+		return nil, fmt.Errorf("failed to parse ENS ABI: %v", err)
 	}
 
 	httpClient := &http.Client{
 		Timeout: 30 * time.Second,
 	}
 
-	return &LPBurnDetector{
-		client:      client,
-		contractABI: contractABI,
-		httpClient:  httpClient,
-	}, nil
-}
-
-func (d *LPBurnDetector) getTokenDetails(address string) (*TokenDetails, error) {
-	reqURL := fmt.Sprintf("https://api.gopluslabs.io/api/v1/token_security/1?contract_addresses=%s", address)
+	messages, err := LoadMessages(cfg.Language, cfg.LocaleDir)
+	if err != nil {
+		return nil, err
+	}
 
-	req, err := http.NewRequest("GET", reqURL, nil)
+	supplyWatchPairs, err := NewAddressSet("SUPPLY_WATCH_PAIRS", cfg.SupplyWatchPairs)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Accept", "*/*")
 
-	resp, err := d.httpClient.Do(req)
+	fromAddrs, err := NewAddressSet("FROM_ADDRS", cfg.FromAddrs)
+	if err != nil {
+		return nil, err
+	}
+	stableAddrs, err := NewAddressSet("STABLE_ADDRS", cfg.StableAddrs)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	projectTokens, err := NewAddressSet("PROJECT_TOKENS", cfg.ProjectTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	quoteTokens := []common.Address{common.HexToAddress(cfg.WethAddr)}
+	for addr := range stableAddrs {
+		quoteTokens = append(quoteTokens, addr)
+	}
 
-	body, err := io.ReadAll(resp.Body)
+	store, err := OpenBurnStore(cfg.BurnStorePath)
 	if err != nil {
 		return nil, err
 	}
 
-	var result GoPlusResponse
-	if err := json.Unmarshal(body, &result); err != nil {
+	processed, err := OpenProcessedTxs(cfg.ProcessedTxsPath, cfg.ProcessedTxsSize, cfg.ProcessedTxsTTL, clock)
+	if err != nil {
 		return nil, err
 	}
 
-	for _, details := range result.Result {
-		return &details, nil
+	breakers := make(map[string]*CircuitBreaker)
+	newBreaker := func(name string) *CircuitBreaker {
+		breakers[name] = NewCircuitBreaker(name, cfg.BreakerThreshold, cfg.BreakerCooldown, clock)
+		return breakers[name]
+	}
+	for _, name := range []string{"GoPlus", "GeckoTerminal", "DexScreener", "Ethplorer"} {
+		newBreaker(name)
+	}
+
+	telegram := NewTelegramNotifier(httpClient, BOT_TOKEN, CHAT_ID, cfg.TelegramMinInterval, cfg.TelegramTimeout, cfg.HTTPMaxAttempts, cfg.HTTPRetryBaseDelay, newBreaker("Telegram"), cfg.TelegramQueueSize, cfg.ReplyThreadTTL, clock)
+	notifiers := []Notifier{telegram}
+	if cfg.ConsoleAlerts {
+		notifiers = append(notifiers, NewConsoleNotifier(os.Stdout, clock))
+	}
+	var slack *SlackNotifier
+	if cfg.SlackWebhookURL != "" {
+		slack = NewSlackNotifier(httpClient, cfg.SlackWebhookURL, cfg.SlackChannel, cfg.SlackTimeout, cfg.HTTPMaxAttempts, cfg.HTTPRetryBaseDelay, newBreaker("Slack"), cfg.TelegramQueueSize, clock)
+		notifiers = append(notifiers, slack)
 	}
+	var webhook *WebhookNotifier
+	if cfg.WebhookURL != "" {
+		webhook = NewWebhookNotifier(httpClient, cfg.WebhookURL, cfg.WebhookSecret, cfg.WebhookTimeout, cfg.HTTPMaxAttempts, cfg.HTTPRetryBaseDelay, newBreaker("Webhook"), cfg.TelegramQueueSize, clock)
+		notifiers = append(notifiers, webhook)
+	}
+
+	d := &LPBurnDetector{
+		client:       client,
+		archive:      archive,
+		clock:        clock,
+		contractABI:  contractABI,
+		routerABI:    routerABI,
+		pairABI:      pairABI,
+		v3PoolABI:    v3PoolABI,
+		chainlinkABI: chainlinkABI,
+		ensABI:       ensABI,
+		httpClient:   httpClient,
+		config:       cfg,
+		telegram:     telegram,
+		slack:        slack,
+		webhook:      webhook,
+		notifiers:    notifiers,
+		quotePrices:  newQuotePriceCache(httpClient, cfg.GeckoNetwork, cfg.QuotePriceTTL, quoteTokens, breakers["GeckoTerminal"], cfg.HTTPMaxAttempts, cfg.HTTPRetryBaseDelay, clock),
+		breakers:     breakers,
+		store:        store,
+		skips:        newSkipLogger(cfg.SkipLogEvery, clock),
+		ens:          newENSCache(),
+		decimals:     newDecimalsCache(),
+		metadata:     newMetadataCache(),
+		symbols:      newSymbolCache(),
+		lpSupplies:   newLPSupplyCache(),
+		dexes:        newDEXCache(),
+		proxies:      newProxyCache(),
+		messages:     messages,
+
+		weth:             common.HexToAddress(cfg.WethAddr),
+		fromAddrs:        fromAddrs,
+		supplyWatchPairs: supplyWatchPairs,
+		supplyWatch:      supplyWatch{last: make(map[common.Address]*big.Int)},
+		stableAddrs:      stableAddrs,
+		projectTokens:    projectTokens,
+		cursor:           NewBlockCursor(cfg.CursorPath),
+		processed:        processed,
+		work:             newWorkQueue(cfg.WorkQueueSize),
+		partials:         partialBurns{pending: make(map[common.Address]*partialBurn)},
+	}
+	if cfg.AlertDigestInterval > 0 {
+		d.digest = &alertDigest{}
+	}
+	if cfg.GoPlusSupported {
+		d.securityProvider = goPlusProvider{d}
+	}
+	d.metadataProvider = dexScreenerProvider{d}
 
-	return nil, fmt.Errorf("no token details found")
+	return d, nil
 }
 
-func (d *LPBurnDetector) getPriceData(address string) (*PriceData, error) {
-	reqURL := fmt.Sprintf("https://app.geckoterminal.com/api/p1/eth/pools/%s?include=pairs&base_token=0", address)
+func (d *LPBurnDetector) getTokenDetails(ctx context.Context, address string) (*TokenDetails, error) {
+	reqURL := fmt.Sprintf("https://api.gopluslabs.io/api/v1/token_security/1?contract_addresses=%s", address)
 
-	req, err := http.NewRequest("GET", reqURL, nil)
+	body, err := d.httpGet(ctx, "GoPlus", reqURL, map[string]string{"Accept": "*/*"})
 	if err != nil {
 		return nil, err
 	}
 
-	// Add headers similar to the original
-	req.Header.Set("Accept", "application/json, text/plain, */*")
-	req.Header.Set("Referrer", "https://www.geckoterminal.com/")
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:124.0) Gecko/20100101 Firefox/124.0")
+	var result GoPlusResponse
+	if err := decodeLenient(ctx, "GoPlus", body, &result); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUpstream, err)
+	}
 
-	resp, err := d.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+	for _, details := range result.Result {
+		return &details, nil
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	return nil, fmt.Errorf("no token details found: %w", ErrNotFound)
+}
+
+// getPriceData prices a pool from GeckoTerminal. baseToken is the index (0
+// or 1) of the pool side to price, normally from geckoBaseToken.
+func (d *LPBurnDetector) getPriceData(ctx context.Context, address string, baseToken int) (*PriceData, error) {
+	reqURL := fmt.Sprintf("https://app.geckoterminal.com/api/p1/%s/pools/%s?include=pairs&base_token=%d", d.config.GeckoNetwork, address, baseToken)
+
+	// Add headers similar to the original
+	body, err := d.httpGet(ctx, "GeckoTerminal", reqURL, map[string]string{
+		"Accept":     "application/json, text/plain, */*",
+		"Referrer":   "https://www.geckoterminal.com/",
+		"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:124.0) Gecko/20100101 Firefox/124.0",
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var result GeckoTerminalResponse
-	if err := json.Unmarshal(body, &result); err != nil {
+	var result GeckoResponse
+	if err := decodeLenient(ctx, "GeckoTerminal", body, &result); err != nil {
 		return nil, err
 	}
 
@@ -274,35 +484,41 @@ func (d *LPBurnDetector) getPriceData(address string) (*PriceData, error) {
 
 	// Get token contract and supply for mcap calculation
 	tokenContract := common.HexToAddress(attr.BaseAddress)
-	supply, err := d.getTokenSupply(tokenContract)
+	supply, err := d.getTokenSupply(ctx, tokenContract, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	decimals, err := d.getTokenDecimals(tokenContract)
+	decimals, err := d.getTokenDecimals(ctx, tokenContract, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	// Calculate market cap
 	priceFloat, _ := strconv.ParseFloat(attr.BasePriceInUsd, 64)
-	supplyFloat := new(big.Float).SetInt(supply)
+	supplyFloat := floatFromInt(supply)
 	decimalsInt := big.NewInt(int64(decimals))
 	tenInt := big.NewInt(10)
 	divisorInt := new(big.Int).Exp(tenInt, decimalsInt, nil)
-	divisor := new(big.Float).SetInt(divisorInt)
-	parsedSupply := new(big.Float).Quo(supplyFloat, divisor)
+	divisor := floatFromInt(divisorInt)
+	parsedSupply := newFloat().Quo(supplyFloat, divisor)
 
-	mcapFloat := new(big.Float).Mul(big.NewFloat(priceFloat), parsedSupply)
+	mcapFloat := newFloat().Mul(floatFrom(priceFloat), parsedSupply)
 	mcap, _ := mcapFloat.Int64()
 
 	// Parse price change
 	priceChange, _ := strconv.ParseFloat(attr.BasePriceInUsdPercentChange, 64)
 
+	reserve := result.Data.Attributes.ReserveInUsd
+	if reserve == "" {
+		reserve = attr.ReserveInUsd
+	}
+	liquidityUSD, _ := strconv.ParseFloat(reserve, 64)
+
 	return &PriceData{
 		Price:   fmt.Sprintf("%.9f", priceFloat),
 		Mcap:    mcap,
-		Swap24h: attr.SwapCount,
+		Swap24h: int64(attr.SwapCount),
 		PriceChange: struct {
 			Total  int64  `json:"total"`
 			Last30 string `json:"last_30"`
@@ -316,19 +532,30 @@ func (d *LPBurnDetector) getPriceData(address string) (*PriceData, error) {
 		},
 		HighestPrice: attr.PriceChangeData.Last86400s.Prices.BaseTokenHighPriceInUsd,
 		LowestPrice:  attr.PriceChangeData.Last86400s.Prices.BaseTokenLowPriceInUsd,
+		LiquidityUSD: liquidityUSD,
 	}, nil
 }
 
-func (d *LPBurnDetector) getTokenSupply(tokenAddress common.Address) (*big.Int, error) {
+// callContract sends reads pinned to a block to the archive node, if one
+// is configured, and everything else to the primary node.
+func (d *LPBurnDetector) callContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if blockNumber != nil {
+		return d.archive.CallContract(ctx, msg, blockNumber)
+	}
+	return d.client.CallContract(ctx, msg, nil)
+}
+
+func (d *LPBurnDetector) getTokenSupply(ctx context.Context, tokenAddress common.Address, blockNumber *big.Int) (*big.Int, error) {
 	data, err := d.contractABI.Pack("totalSupply")
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := d.client.CallContract(context.Background(), ethereum.CallMsg{
+	result, err := d.callContract(ctx, ethereum.CallMsg{
 		To:   &tokenAddress,
 		Data: data,
-	}, nil)
+		Gas:  d.config.CallGasLimit,
+	}, blockNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -342,16 +569,12 @@ func (d *LPBurnDetector) getTokenSupply(tokenAddress common.Address) (*big.Int,
 	return supply, nil
 }
 
-func (d *LPBurnDetector) getTokenDecimals(tokenAddress common.Address) (uint8, error) {
-	data, err := d.contractABI.Pack("decimals")
-	if err != nil {
-		return 0, err
+func (d *LPBurnDetector) getTokenDecimals(ctx context.Context, tokenAddress common.Address, blockNumber *big.Int) (uint8, error) {
+	if decimals, ok := d.decimals.get(tokenAddress); ok {
+		return decimals, nil
 	}
 
-	result, err := d.client.CallContract(context.Background(), ethereum.CallMsg{
-		To:   &tokenAddress,
-		Data: data,
-	}, nil)
+	result, err := d.callMetadata(ctx, tokenAddress, "decimals", blockNumber)
 	if err != nil {
 		return 0, err
 	}
@@ -362,41 +585,55 @@ func (d *LPBurnDetector) getTokenDecimals(tokenAddress common.Address) (uint8, e
 		return 0, err
 	}
 
+	d.decimals.set(tokenAddress, decimals)
 	return decimals, nil
 }
 
-func (d *LPBurnDetector) getTokenName(tokenAddress common.Address) (string, error) {
-	data, err := d.contractABI.Pack("name")
+func (d *LPBurnDetector) getTokenName(ctx context.Context, tokenAddress common.Address) (string, error) {
+	result, err := d.callMetadata(ctx, tokenAddress, "name", nil)
 	if err != nil {
 		return "", err
 	}
 
-	result, err := d.client.CallContract(context.Background(), ethereum.CallMsg{
-		To:   &tokenAddress,
-		Data: data,
-	}, nil)
+	var name string
+	err = d.contractABI.UnpackIntoInterface(&name, "name", result)
 	if err != nil {
 		return "", err
 	}
 
-	var name string
-	err = d.contractABI.UnpackIntoInterface(&name, "name", result)
+	return name, nil
+}
+
+func (d *LPBurnDetector) getTokenSymbol(ctx context.Context, tokenAddress common.Address) (string, error) {
+	if symbol, ok := d.symbols.get(tokenAddress); ok {
+		return symbol, nil
+	}
+
+	result, err := d.callMetadata(ctx, tokenAddress, "symbol", nil)
 	if err != nil {
 		return "", err
 	}
 
-	return name, nil
+	var symbol string
+	err = d.contractABI.UnpackIntoInterface(&symbol, "symbol", result)
+	if err != nil {
+		return "", err
+	}
+
+	d.symbols.set(tokenAddress, symbol)
+	return symbol, nil
 }
 
-func (d *LPBurnDetector) getToken0(lpAddress common.Address) (common.Address, error) {
+func (d *LPBurnDetector) getToken0(ctx context.Context, lpAddress common.Address) (common.Address, error) {
 	data, err := d.contractABI.Pack("token0")
 	if err != nil {
 		return common.Address{}, err
 	}
 
-	result, err := d.client.CallContract(context.Background(), ethereum.CallMsg{
+	result, err := d.client.CallContract(ctx, ethereum.CallMsg{
 		To:   &lpAddress,
 		Data: data,
+		Gas:  d.config.CallGasLimit,
 	}, nil)
 	if err != nil {
 		return common.Address{}, err
@@ -411,15 +648,16 @@ func (d *LPBurnDetector) getToken0(lpAddress common.Address) (common.Address, er
 	return token0, nil
 }
 
-func (d *LPBurnDetector) getToken1(lpAddress common.Address) (common.Address, error) {
+func (d *LPBurnDetector) getToken1(ctx context.Context, lpAddress common.Address) (common.Address, error) {
 	data, err := d.contractABI.Pack("token1")
 	if err != nil {
 		return common.Address{}, err
 	}
 
-	result, err := d.client.CallContract(context.Background(), ethereum.CallMsg{
+	result, err := d.client.CallContract(ctx, ethereum.CallMsg{
 		To:   &lpAddress,
 		Data: data,
+		Gas:  d.config.CallGasLimit,
 	}, nil)
 	if err != nil {
 		return common.Address{}, err
@@ -434,18 +672,68 @@ func (d *LPBurnDetector) getToken1(lpAddress common.Address) (common.Address, er
 	return token1, nil
 }
 
-func (d *LPBurnDetector) getTokenBalance(tokenAddress, holderAddress common.Address) (*big.Int, error) {
+// isKnownLP reports whether the LP token's name, or failing that its
+// symbol, contains one of LPNamePatterns.
+func (d *LPBurnDetector) isKnownLP(ctx context.Context, lpAddress common.Address, name string) bool {
+	if containsAny(name, d.config.LPNamePatterns) {
+		return true
+	}
+	symbol, err := d.getTokenSymbol(ctx, lpAddress)
+	if err != nil {
+		return false
+	}
+	return containsAny(symbol, d.config.LPNamePatterns)
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// circulatingLPSupply is lpSupply less what the dead and zero addresses
+// held in the block before the burn. It falls back to lpSupply when those
+// balances can't be read.
+func (d *LPBurnDetector) circulatingLPSupply(ctx context.Context, lpAddress common.Address, lpSupply, blockNumber *big.Int) *big.Int {
+	if blockNumber == nil || blockNumber.Sign() == 0 {
+		return lpSupply
+	}
+	parent := new(big.Int).Sub(blockNumber, big.NewInt(1))
+
+	circulating := new(big.Int).Set(lpSupply)
+	for _, holder := range []common.Address{common.HexToAddress(d.config.DeadAddr), {}} {
+		burned, err := d.getTokenBalance(ctx, lpAddress, holder, parent)
+		if err != nil {
+			logf(ctx, "Failed to get prior LP burns, using total supply: %v", err)
+			return lpSupply
+		}
+		circulating.Sub(circulating, burned)
+	}
+	if circulating.Sign() <= 0 {
+		return lpSupply
+	}
+	return circulating
+}
+
+func (d *LPBurnDetector) getTokenBalance(ctx context.Context, tokenAddress, holderAddress common.Address, blockNumber *big.Int) (*big.Int, error) {
 	data, err := d.contractABI.Pack("balanceOf", holderAddress)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := d.client.CallContract(context.Background(), ethereum.CallMsg{
+	result, err := d.callContract(ctx, ethereum.CallMsg{
 		To:   &tokenAddress,
 		Data: data,
-	}, nil)
+		Gas:  d.config.CallGasLimit,
+	}, blockNumber)
 	if err != nil {
-		return nil, err
+		return nil, revertError(err)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%w: empty balanceOf result", ErrReverted)
 	}
 
 	var balance *big.Int
@@ -457,238 +745,757 @@ func (d *LPBurnDetector) getTokenBalance(tokenAddress, holderAddress common.Addr
 	return balance, nil
 }
 
-func (d *LPBurnDetector) sendTelegramMessage(message string) error {
-	telegramURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", BOT_TOKEN)
-
-	data := url.Values{}
-	data.Set("chat_id", CHAT_ID)
-	data.Set("text", message)
-	data.Set("parse_mode", "HTML")
-	data.Set("disable_web_page_preview", "true")
+// burnResult is the outcome of analyzing one LP token burned in a tx.
+type burnResult struct {
+	Kind      lpEventKind
+	value     *big.Int // raw LP amount
+	Message   string
+	LogoURL   string
+	BaseToken int // GeckoTerminal base_token of the project token
+	Record    BurnRecord
+}
 
-	resp, err := d.httpClient.PostForm(telegramURL, data)
+// processLPBurn handles a candidate burn transaction. logs are the
+// dead-address Transfer events the tx emitted; when empty the tx itself must
+// be a direct transfer() call. Every LP token burned in the tx is analyzed
+// and reported in a single alert.
+//
+// State reads (supplies, decimals, balances) are made at blockNumber so the
+// figures reflect the time of the burn; nil reads the latest state.
+func (d *LPBurnDetector) processLPBurn(ctx context.Context, txHash common.Hash, blockNumber *big.Int, logs []types.Log) error {
+	// Get transaction details
+	tx, err := d.getMinedTransaction(ctx, txHash)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("telegram API error: %s", string(body))
+	var transfers []burnTransfer
+	if len(logs) > 0 {
+		transfers = d.transfersFromLogs(logs)
+		removals, err := d.removalsFromLogs(ctx, txHash, logs)
+		if err != nil {
+			return err
+		}
+		transfers = append(transfers, removals...)
+	} else {
+		transfer, err := d.transferFromCallData(tx)
+		if err != nil {
+			return err
+		}
+		transfers = []burnTransfer{transfer}
 	}
 
-	return nil
-}
+	if len(transfers) == 0 {
+		return skipf("no dead-address transfers in tx")
+	}
 
-func (d *LPBurnDetector) processLPBurn(txHash common.Hash) error {
-	// Get transaction details
-	tx, isPending, err := d.client.TransactionByHash(context.Background(), txHash)
-	if err != nil {
-		return fmt.Errorf("failed to get transaction: %v", err)
+	var results []*burnResult
+	var errs []string
+	allSkips := true
+	for _, transfer := range aggregateTransfers(transfers) {
+		result, err := d.analyzeLPBurn(ctx, tx, transfer.LP, transfer.Value, blockNumber, transfer.Kind)
+		if err != nil {
+			errs = append(errs, err.Error())
+			allSkips = allSkips && isSkip(err)
+			continue
+		}
+		results = append(results, result)
 	}
 
-	if isPending {
-		return fmt.Errorf("transaction is still pending")
+	if len(results) == 0 {
+		if allSkips {
+			return skipf("%s", strings.Join(errs, "; "))
+		}
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
 	}
 
-	// Check if it's a transfer function call (a9059cbb)
-	if len(tx.Data()) < 4 {
-		return fmt.Errorf("transaction data too short")
+	if ctx.Err() != nil {
+		logf(ctx, "⏱️ Deadline hit while enriching tx %s, sending best-effort alert", txHash.Hex())
 	}
 
-	functionSelector := hex.EncodeToString(tx.Data()[:4])
-	if functionSelector != "a9059cbb" {
-		return fmt.Errorf("not a transfer function call: %s", functionSelector)
+	if d.config.BurnGraceWindow > 0 {
+		if results = d.holdPartialBurns(ctx, tx, blockNumber, results); len(results) == 0 {
+			return nil
+		}
 	}
 
-	lpAddress := *tx.To()
+	d.alertResults(ctx, txHash, blockNumber, results)
+	return nil
+}
 
-	// Get LP token name to verify it's a Uniswap LP
-	lpName, err := d.getTokenName(lpAddress)
-	if err != nil {
-		return fmt.Errorf("failed to get LP name: %v", err)
+// alertResults persists and sends the results of one tx as a single alert,
+// or adds them to the digest in digest mode.
+func (d *LPBurnDetector) alertResults(ctx context.Context, txHash common.Hash, blockNumber *big.Int, results []*burnResult) {
+	if d.config.DryRun {
+		for _, result := range results {
+			logf(ctx, "🧪 Dry run: LP burn of %s (%s) in tx %s, not alerting", result.Record.TokenSymbol, result.Record.TokenAddress, txHash.Hex())
+		}
+		return
 	}
 
-	if !strings.Contains(lpName, "Uniswap") {
-		return fmt.Errorf("not a Uniswap LP: %s", lpName)
+	var messages []string
+	var mcap int64
+	for _, result := range results {
+		if blockNumber != nil {
+			result.Record.BlockNumber = blockNumber.Uint64()
+		}
+		result.Record.EventID = eventID(ctx)
+		if result.Kind == lpBurn {
+			if err := d.store.Add(result.Record); err != nil {
+				logf(ctx, "Failed to persist burn record: %v", err)
+			}
+		}
+		messages = append(messages, result.Message)
+		if result.Record.Mcap > mcap {
+			mcap = result.Record.Mcap
+		}
 	}
 
-	// Decode transfer function data
-	var to common.Address
-	var value *big.Int
-
-	err = d.contractABI.UnpackIntoInterface(&[]interface{}{&to, &value}, "transfer", tx.Data()[4:])
-	if err != nil {
-		return fmt.Errorf("failed to decode transfer data: %v", err)
+	if d.digest != nil {
+		d.digest.add(results...)
+		return
 	}
 
-	// Check if tokens are being sent to dead address
-	if strings.ToLower(to.Hex()) != DEAD_ADDR {
-		return fmt.Errorf("tokens not sent to dead address: %s", to.Hex())
+	alert := Alert{Text: d.withAlertID(ctx, strings.Join(messages, "\n\n━━━━━━━━━━━━━━━\n\n")), Mcap: mcap}
+	if len(results) == 1 {
+		alert.Thread = strings.ToLower(results[0].Record.LPAddress)
+		if d.config.AlertLogos {
+			alert.PhotoURL = results[0].LogoURL
+		}
 	}
 
-	// Get LP token supply
-	lpSupply, err := d.getTokenSupply(lpAddress)
-	if err != nil {
-		return fmt.Errorf("failed to get LP supply: %v", err)
+	// Follow up on a lone burn whose pool GeckoTerminal hasn't indexed yet;
+	// with several burns per alert it's ambiguous which Mcap line to edit.
+	if d.config.LatePriceWindow > 0 && d.config.GeckoSupported && len(results) == 1 && mcap == 0 {
+		result := results[0]
+		logCtx := withEventID(context.Background(), eventID(ctx))
+		alert.OnSent = func(msg TelegramMessage, text string) {
+			go d.followUpPrice(logCtx, result, msg, text)
+		}
 	}
 
-	// Calculate burn percentage
-	burnedFloat := new(big.Float).SetInt(value)
-	supplyFloat := new(big.Float).SetInt(lpSupply)
-	eighteenDecimals := new(big.Float).SetInt(big.NewInt(1000000000000000000)) // 10^18
+	d.notify(alert)
+}
 
-	burnedLP := new(big.Float).Quo(burnedFloat, eighteenDecimals)
-	parsedSupply := new(big.Float).Quo(supplyFloat, eighteenDecimals)
+// getMinedTransaction fetches a tx that a log has already shown to be mined.
+// A load-balanced RPC may briefly answer from a node that hasn't seen it yet,
+// so not-found and pending answers are retried TxLookupAttempts times.
+func (d *LPBurnDetector) getMinedTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, error) {
+	for attempt := 1; ; attempt++ {
+		tx, isPending, err := d.client.TransactionByHash(ctx, txHash)
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("failed to get transaction: %v", err)
+		}
+		if err == nil && !isPending {
+			return tx, nil
+		}
 
-	percentage := new(big.Float).Quo(parsedSupply, burnedLP)
-	percentage.Mul(percentage, big.NewFloat(100))
+		if attempt >= d.config.TxLookupAttempts || !spendRetry(ctx, d.config.TxLookupDelay) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to get transaction: %v", err)
+			}
+			return nil, fmt.Errorf("transaction is still pending")
+		}
 
-	// Get token addresses from LP
-	token0, err := d.getToken0(lpAddress)
-	if err != nil {
-		return fmt.Errorf("failed to get token0: %v", err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-d.clock.After(d.config.TxLookupDelay):
+		}
 	}
+}
 
-	token1, err := d.getToken1(lpAddress)
-	if err != nil {
-		return fmt.Errorf("failed to get token1: %v", err)
-	}
+// lpEventKind is what happened to the LP tokens an alert reports on.
+type lpEventKind int
 
-	// Determine which token is not WETH
-	var tokenContract common.Address
-	if strings.ToLower(token0.Hex()) == WETH_ADDR {
-		tokenContract = token1
-	} else {
-		tokenContract = token0
-	}
+const (
+	lpBurn lpEventKind = iota
+	lpAdd
+	lpRemove   // Uniswap V2 pair Burn
+	lpV3Remove // Uniswap V3 pool Burn; value is liquidity, not LP tokens
+)
 
-	// Get token details
-	details, err := d.getTokenDetails(tokenContract.Hex())
-	if err != nil {
-		log.Printf("Failed to get token details: %v", err)
-		details = &TokenDetails{
-			TokenName:   "Unknown",
-			TokenSymbol: "UNK",
-			IsHoneypot:  "undefined",
-			BuyTax:      "0",
-			SellTax:     "0",
-			HolderCount: "0",
-			Holders:     []Holder{},
+// analyzeLPBurn verifies lpAddress is a Uniswap LP and gathers everything
+// the alert reports about value of it being burned or, for lpAdd, minted.
+func (d *LPBurnDetector) analyzeLPBurn(ctx context.Context, tx *types.Transaction, lpAddress common.Address, value *big.Int, blockNumber *big.Int, kind lpEventKind) (*burnResult, error) {
+	txHash := tx.Hash()
+
+	// V3 pools aren't ERC20s, so have neither a name to check nor a supply
+	lpSupply := big.NewInt(0)
+	if kind != lpV3Remove {
+		// Get LP token name to verify it's a Uniswap LP
+		lpName, err := d.getTokenName(ctx, lpAddress)
+		if err != nil {
+			return nil, readError("failed to get LP name", err)
+		}
+
+		if !d.isKnownLP(ctx, lpAddress, lpName) {
+			return nil, skipf("not a known LP: %s", lpName)
+		}
+
+		// Get LP token supply
+		lpSupply, err = d.getTokenSupply(ctx, lpAddress, blockNumber)
+		if err != nil {
+			return nil, readError("failed to get LP supply", err)
 		}
 	}
 
-	// Get price data
-	priceData, err := d.getPriceData(lpAddress.Hex())
-	if err != nil {
-		log.Printf("Failed to get price data: %v", err)
-		priceData = &PriceData{
-			Price: "0",
-			Mcap:  0,
+	// Calculate burn percentage
+	percentSupply := lpSupply
+	if kind == lpBurn && d.config.CirculatingLPPercent {
+		percentSupply = d.circulatingLPSupply(ctx, lpAddress, lpSupply, blockNumber)
+	}
+	burnedFloat := floatFromInt(value)
+
+	// V2 LP tokens have 18 decimals, but forks may not; V3 liquidity has
+	// no decimals() to read
+	lpDecimals := uint8(18)
+	if kind != lpV3Remove {
+		if decimals, err := d.getTokenDecimals(ctx, lpAddress, blockNumber); err != nil {
+			logf(ctx, "Failed to get LP decimals, assuming 18: %v", err)
+		} else {
+			lpDecimals = decimals
+		}
+	}
+	burnedLP, percentage := lpBurnShare(value, percentSupply, lpDecimals)
+
+	// A removal's LP is already burned by the pair at blockNumber, so its
+	// share is of the supply before it
+	removedPercent := 0.0
+	if kind == lpRemove {
+		before := new(big.Int).Add(lpSupply, value)
+		removedPercent, _ = newFloat().Quo(burnedFloat, floatFromInt(before)).Float64()
+		removedPercent *= 100
+		if removedPercent < d.config.MinRemovalPercent {
+			return nil, skipf("removal of %.2f%% of %s LP below minimum %.2f%%", removedPercent, lpAddress.Hex(), d.config.MinRemovalPercent)
 		}
 	}
 
-	// Get token supply and balance
-	tokenSupply, err := d.getTokenSupply(tokenContract)
+	// Get token addresses from LP
+	token0, err := d.getToken0(ctx, lpAddress)
 	if err != nil {
-		log.Printf("Failed to get token supply: %v", err)
-		tokenSupply = big.NewInt(0)
+		return nil, readError("failed to get token0", err)
 	}
 
-	tokenDecimals, err := d.getTokenDecimals(tokenContract)
+	token1, err := d.getToken1(ctx, lpAddress)
 	if err != nil {
-		log.Printf("Failed to get token decimals: %v", err)
-		tokenDecimals = 18
+		return nil, readError("failed to get token1", err)
 	}
 
-	tokenBalance, err := d.getTokenBalance(tokenContract, tokenContract)
-	if err != nil {
-		log.Printf("Failed to get token balance: %v", err)
-		tokenBalance = big.NewInt(0)
+	// Determine which token is the project token
+	tokenContract, ok := d.selectProjectToken(lpAddress, token0, token1)
+	if !ok {
+		return nil, skipf("pool %s pairs two quote tokens (%s, %s), not a token launch", lpAddress.Hex(), token0.Hex(), token1.Hex())
 	}
 
-	// Calculate clogged percentage
-	decimalsInt := big.NewInt(int64(tokenDecimals))
-	tenInt := big.NewInt(10)
-	divisorInt := new(big.Int).Exp(tenInt, decimalsInt, nil)
-	divisor := new(big.Float).SetInt(divisorInt)
+	if kind == lpBurn && d.config.FirstBurnOnly && d.store.HasToken(tokenContract.Hex()) {
+		return nil, skipf("token %s already had a burn (first-burn-only mode)", tokenContract.Hex())
+	}
+
+	// The details, price and token reads are independent, so they run
+	// concurrently, each falling back on its own if it fails
+	var (
+		details      *TokenDetails
+		priceData    *PriceData
+		tokenSupply  *big.Int
+		tokenBalance *big.Int
+		poolBalance  *big.Int
+		quoteSymbol  string
+
+		// Without decimals the clogged amount is computed assuming 18 and
+		// marked approximate in the alert
+		tokenDecimals uint8
+		decimalsKnown bool
+	)
+	var g errgroup.Group
+	g.SetLimit(d.config.EnrichConcurrency)
+	enrich := func(call func(ctx context.Context)) {
+		g.Go(func() error {
+			callCtx, cancel := context.WithTimeout(ctx, d.config.EnrichTimeout)
+			defer cancel()
+			call(callCtx)
+			return nil
+		})
+	}
+	enrich(func(ctx context.Context) {
+		var err error
+		if details, err = d.getDetails(ctx, tokenContract.Hex()); err != nil {
+			logf(ctx, "Failed to get token details: %v", err)
+			details = mergeDetails(nil, nil)
+		}
+	})
+	enrich(func(ctx context.Context) {
+		quoteToken := token1
+		if tokenContract == token1 {
+			quoteToken = token0
+		}
+		var err error
+		if quoteSymbol, err = d.getTokenSymbol(ctx, quoteToken); err != nil {
+			logf(ctx, "Failed to get quote token symbol: %v", err)
+		}
+	})
+	enrich(func(ctx context.Context) {
+		var err error
+		if priceData, err = d.getPriceWithFallback(ctx, lpAddress, token0, token1, tokenContract, blockNumber); err != nil {
+			logf(ctx, "Failed to get price data: %v", err)
+			priceData = &PriceData{
+				Price: "0",
+				Mcap:  0,
+			}
+		} else {
+			logf(ctx, "💲 Priced %s from %s", tokenContract.Hex(), priceData.Source)
+		}
+	})
+	enrich(func(ctx context.Context) {
+		var err error
+		if tokenSupply, err = d.getTokenSupply(ctx, tokenContract, blockNumber); err != nil {
+			logf(ctx, "Failed to get token supply: %v", err)
+			tokenSupply = big.NewInt(0)
+		}
+	})
+	enrich(func(ctx context.Context) {
+		var err error
+		if tokenDecimals, err = d.getTokenDecimals(ctx, tokenContract, blockNumber); err != nil {
+			logf(ctx, "Failed to get token decimals, assuming 18: %v", err)
+			tokenDecimals = 18
+		} else {
+			decimalsKnown = true
+		}
+	})
+	enrich(func(ctx context.Context) {
+		var err error
+		// A balance that can't be read leaves tokenBalance nil and the
+		// clogged line out, rather than reporting nothing clogged
+		if tokenBalance, err = d.getTokenBalance(ctx, tokenContract, tokenContract, blockNumber); errors.Is(err, ErrReverted) {
+			logf(ctx, "Token balanceOf reverts, omitting clogged amount: %v", err)
+		} else if err != nil {
+			logf(ctx, "Failed to get token balance: %v", err)
+		}
+	})
+	enrich(func(ctx context.Context) {
+		var err error
+		if poolBalance, err = d.getTokenBalance(ctx, tokenContract, lpAddress, blockNumber); err != nil {
+			logf(ctx, "Failed to get pool token balance: %v", err)
+		}
+	})
+	g.Wait()
+
+	// Simulate the taxes on-chain when GoPlus couldn't provide them
+	if d.config.TaxSimulation && (taxUnknown(details.BuyTax) || taxUnknown(details.SellTax)) {
+		simCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+		buyTax, sellTax, err := d.simulateTaxes(simCtx, tokenContract)
+		cancel()
+		if err != nil {
+			logf(ctx, "Failed to simulate taxes: %v", err)
+		} else {
+			details.BuyTax = fmt.Sprintf("%.4f", buyTax)
+			details.SellTax = fmt.Sprintf("%.4f", sellTax)
+		}
+	}
 
-	parsedTokenSupply := new(big.Float).Quo(new(big.Float).SetInt(tokenSupply), divisor)
-	tokenHolding := new(big.Float).Quo(new(big.Float).SetInt(tokenBalance), divisor)
+	if d.config.MinLiquidityUSD > 0 {
+		if priceData.LiquidityUSD <= 0 {
+			if !d.config.AlertOnUnknownLiquidity {
+				return nil, skipf("pool %s liquidity unknown", lpAddress.Hex())
+			}
+		} else if priceData.LiquidityUSD < d.config.MinLiquidityUSD {
+			return nil, skipf("pool %s liquidity $%.0f below minimum $%.0f", lpAddress.Hex(), priceData.LiquidityUSD, d.config.MinLiquidityUSD)
+		}
+	}
 
-	cloggedPercentage := new(big.Float).Quo(tokenHolding, parsedTokenSupply)
-	cloggedPercentage.Mul(cloggedPercentage, big.NewFloat(100))
+	// Share of the token supply held by the pool
+	supplyInLP := -1.0
+	if tokenSupply.Sign() > 0 && poolBalance != nil {
+		supplyInLP, _ = newFloat().Quo(floatFromInt(poolBalance), floatFromInt(tokenSupply)).Float64()
+		supplyInLP *= 100
+	}
+
+	// Calculate clogged percentage
+	cloggedKnown := tokenBalance != nil && tokenSupply.Sign() > 0
+	var cloggedFormatted, cloggedPercentageFormatted float64
+	if cloggedKnown {
+		decimalsInt := big.NewInt(int64(tokenDecimals))
+		tenInt := big.NewInt(10)
+		divisorInt := new(big.Int).Exp(tenInt, decimalsInt, nil)
+		divisor := floatFromInt(divisorInt)
+
+		parsedTokenSupply := newFloat().Quo(floatFromInt(tokenSupply), divisor)
+		tokenHolding := newFloat().Quo(floatFromInt(tokenBalance), divisor)
+
+		cloggedPercentage := newFloat().Quo(tokenHolding, parsedTokenSupply)
+		cloggedPercentage.Mul(cloggedPercentage, floatFrom(100))
+
+		cloggedFormatted, _ = tokenHolding.Float64()
+		cloggedPercentageFormatted, _ = cloggedPercentage.Float64()
+	}
 
 	// Format burned LP value
 	burnedFormatted, _ := burnedLP.Float64()
 	percentageFormatted, _ := percentage.Float64()
-	cloggedFormatted, _ := tokenHolding.Float64()
-	cloggedPercentageFormatted, _ := cloggedPercentage.Float64()
 
 	// Create message
-	honeypotStatus := "Unknown 🟨"
+	msg := d.messages
+	honeypotStatus := msg.Unknown + " 🟨"
 	if details.IsHoneypot == "0" {
-		honeypotStatus = "False 🟩"
+		honeypotStatus = msg.False + " 🟩"
 	} else if details.IsHoneypot == "1" {
-		honeypotStatus = "True 🟥"
+		honeypotStatus = msg.True + " 🟥"
+	}
+
+	contractFlags := ""
+	switch details.IsOpenSource {
+	case "1":
+		contractFlags += fmt.Sprintf("\n        <b>⎿ %s:</b> ✅", msg.OpenSource)
+	case "0":
+		contractFlags += fmt.Sprintf("\n        <b>⎿ %s:</b> ❌", msg.OpenSource)
+	}
+	switch details.IsProxy {
+	case "1":
+		contractFlags += fmt.Sprintf("\n        <b>⎿ %s:</b> %s ⚠️", msg.Proxy, msg.True)
+	case "0":
+		contractFlags += fmt.Sprintf("\n        <b>⎿ %s:</b> %s", msg.Proxy, msg.False)
 	}
 
 	// Format buy/sell tax
-	buyTax := "Unknown 🟨"
-	if details.BuyTax != "" && details.BuyTax != "0" {
+	highTax := false
+	buyTax := msg.Unknown + " 🟨"
+	if !taxUnknown(details.BuyTax) {
 		if tax, err := strconv.ParseFloat(details.BuyTax, 64); err == nil {
 			buyTax = fmt.Sprintf("%.1f%%", tax*100)
+			highTax = highTax || d.isHighTax(tax)
 		}
 	}
 
-	sellTax := "Unknown 🟨"
-	if details.SellTax != "" && details.SellTax != "0" {
+	sellTax := msg.Unknown + " 🟨"
+	if !taxUnknown(details.SellTax) {
 		if tax, err := strconv.ParseFloat(details.SellTax, 64); err == nil {
 			sellTax = fmt.Sprintf("%.1f%%", tax*100)
+			highTax = highTax || d.isHighTax(tax)
 		}
 	}
 
+	cloggedLine := ""
+	if cloggedKnown {
+		clogged := formatNumber(int64(cloggedFormatted))
+		if !decimalsKnown {
+			clogged = "~" + clogged + " (" + msg.Approximate + ")"
+		}
+		cloggedLine = fmt.Sprintf("\n        <b>⎿ %s:</b> %s (%.1f%%)", msg.Clogged, clogged, cloggedPercentageFormatted)
+	}
+
+	if quoteSymbol == "" {
+		quoteSymbol = msg.Unknown
+	}
+	dexLine := fmt.Sprintf("\n🔀 <b>%s:</b> %s/%s", msg.Pair, html.EscapeString(details.TokenSymbol), html.EscapeString(quoteSymbol))
+	if d.config.DEXProbe {
+		dex := d.resolveDEX(ctx, lpAddress)
+		if dex == unknownDEX {
+			dex = msg.UnknownDEX
+		}
+		dexLine += fmt.Sprintf("\n🏦 <b>%s:</b> %s", msg.DEX, dex)
+	}
+	dexLine += formatLinks(msg.Links, details.Links)
+
+	header := ""
+	if kind == lpBurn && d.config.RecentAddLookback > 0 && d.burnedOnlyRecentLP(ctx, lpAddress, value, lpSupply, blockNumber) {
+		header = fmt.Sprintf("⚠️ <b>%s</b> ⚠️\n", msg.RecentLPOnly)
+	}
+	snipeLinks := fmt.Sprintf(`
+<b>%s:</b> <a href="https://t.me/MaestroSniperBot?start=%s">Maestro</a> (<a href="https://t.me/MaestroProBot?start=%s">Pro</a>) | <a href="https://t.me/BananaGunSniper_bot?start=snp_Atasya_%s">Banana</a>`,
+		msg.Snipe, tokenContract.Hex(), tokenContract.Hex(), tokenContract.Hex())
+	if highTax {
+		header += fmt.Sprintf("⚠️ <b>%s</b> (%s %.0f%%) ⚠️\n", msg.HighTax, msg.Over, d.config.MaxSafeTax*100)
+		if d.config.HideSnipeOnHighTax {
+			snipeLinks = ""
+		}
+	}
+	var risk riskAssessment
+	if kind == lpBurn {
+		risk = d.assessRisk(details, lpAddress, percentageFormatted)
+		header = d.formatRisk(risk) + header
+	}
+
+	// Format holder count
+	holderCount := msg.Unknown
+	holderCountInt, holderCountKnown := d.resolveHolderCount(ctx, details, tokenContract)
+	if holderCountKnown {
+		holderCount = formatNumber(holderCountInt)
+	}
+
 	// Format top holders
-	topHolders := "N/A"
-	if len(details.Holders) > 0 {
-		var holderStrings []string
-		for i, holder := range details.Holders {
-			if i >= 2 { // Only show top 2
-				break
-			}
-			percent, _ := strconv.ParseFloat(holder.Percent, 64)
-			holderStrings = append(holderStrings, fmt.Sprintf("<a href=\"https://etherscan.io/address/%s\">%.4f%%</a>", holder.Address, percent))
+	holderStrings := d.formatTopHolders(ctx, details.Holders, d.config.TopHolders)
+	topHoldersLine := func(n int) string {
+		if d.config.TopHolders == 0 {
+			return ""
+		}
+		topHolders := msg.NotAvailable
+		if n > 0 {
+			topHolders = strings.Join(holderStrings[:n], "|")
+		}
+		return fmt.Sprintf("\n        <b>⎿ %s:</b> %s", msg.TopHolders, topHolders)
+	}
+
+	// Low-level transaction details for manual investigation
+	verbose := ""
+	if d.config.VerboseAlerts {
+		verbose = d.formatVerboseSection(ctx, tx, value, lpAddress, tokenContract)
+	}
+
+	title := msg.NewBurn
+	method := d.burnMethod(tx, lpAddress)
+	amountLine := fmt.Sprintf("<b>⎿ %s:</b> %.1f(%.2f%%)\n        <b>⎿ %s:</b> %s", msg.Burned, burnedFormatted, percentageFormatted, msg.BurnMethod, method)
+	if lpHolders, err := strconv.ParseInt(details.LPHolderCount, 10, 64); err == nil && lpHolders > 0 {
+		amountLine += fmt.Sprintf("\n        <b>⎿ %s:</b> %s (%.2f%% %s)",
+			msg.LPHolders, formatNumber(lpHolders), d.burnedLPPercent(details.LPHolders), msg.BurnedPerGoPlus)
+	}
+	switch kind {
+	case lpAdd:
+		title = msg.LiquidityAdded
+		amountLine = fmt.Sprintf("<b>⎿ %s:</b> %.1f LP", msg.Added, burnedFormatted)
+	case lpRemove:
+		title = msg.LiquidityRemovedV2
+		amountLine = fmt.Sprintf("<b>⎿ %s:</b> %.1f LP (%.2f%%)", msg.Removed, burnedFormatted, removedPercent)
+	case lpV3Remove:
+		title = msg.LiquidityRemovedV3
+		amountLine = fmt.Sprintf("<b>⎿ %s:</b> %s %s", msg.Removed, value.String(), msg.Liquidity)
+	}
+	if supplyInLP >= 0 {
+		amountLine += fmt.Sprintf("\n        <b>⎿ %s:</b> %.2f%%", msg.SupplyInLP, supplyInLP)
+	}
+
+	render := func(holdersLine string) string {
+		return fmt.Sprintf(`%s%s
+<a href="%s/address/%s">%s</a><b>(%s)</b>
+<code>%s</code>%s
+
+💰<b>%s:</b> $%s
+        <b>⎿ %s:</b> <a href="%s/tx/%s">%s</a>
+        %s
+
+🔵 %s : %s
+        <b>⎿ %s:</b> %s
+        <b>⎿ %s:</b> %s%s%s
+
+👤 %s: %s%s%s
+
+<b>%s:</b> <a href="https://www.dextools.io/app/en/%s/pair-explorer/%s">DexTools</a> | <a href="https://dexscreener.com/%s/%s">DexScreener</a> | <a href="https://dexspy.io/%s/token/%s">DexSpy</a>%s%s`,
+			header, title, d.config.ExplorerURL, tokenContract.Hex(), details.TokenName, details.TokenSymbol, tokenContract.Hex(), dexLine,
+			msg.Mcap, formatNumber(priceData.Mcap), msg.Hash, d.config.ExplorerURL, txHash.Hex(), msg.ClickHere, amountLine,
+			msg.Honeypot, honeypotStatus, msg.BuyTax, buyTax, msg.SellTax, sellTax, cloggedLine, contractFlags,
+			msg.HolderCount, holderCount, holdersLine, verbose,
+			msg.Chart, d.config.DexToolsChain, tokenContract.Hex(), d.config.DexScreenerChain, tokenContract.Hex(), d.config.DexSpyChain, tokenContract.Hex(), snipeLinks,
+			d.alertFooter())
+	}
+
+	// Drop holders from the end until the alert fits in one message
+	shown := len(holderStrings)
+	message := render(topHoldersLine(shown))
+	for shown > 0 && telegramLength(message) > telegramMaxMessageLength {
+		shown--
+		message = render(topHoldersLine(shown))
+	}
+
+	return &burnResult{
+		Kind:      kind,
+		value:     value,
+		Message:   message,
+		LogoURL:   details.LogoURL,
+		BaseToken: geckoBaseToken(token0, tokenContract),
+		Record: BurnRecord{
+			TxHash:         txHash.Hex(),
+			LPAddress:      lpAddress.Hex(),
+			TokenAddress:   tokenContract.Hex(),
+			TokenName:      details.TokenName,
+			TokenSymbol:    details.TokenSymbol,
+			BurnedLP:       burnedFormatted,
+			BurnPercent:    percentageFormatted,
+			BurnedUSD:      burnedLiquidityUSD(priceData.LiquidityUSD, value, lpSupply),
+			PriceUSD:       priceData.Price,
+			PriceSource:    priceData.Source,
+			BurnMethod:     method,
+			Mcap:           priceData.Mcap,
+			IsHoneypot:     details.IsHoneypot,
+			BuyTax:         details.BuyTax,
+			SellTax:        details.SellTax,
+			Clogged:        cloggedFormatted,
+			CloggedPercent: cloggedPercentageFormatted,
+			HolderCount:    holderCountInt,
+			Risk:           risk.Level,
+			RiskScore:      risk.Score,
+			RiskSignals:    risk.Signals,
+			DetectedAt:     d.clock.Now(),
+		},
+	}, nil
+}
+
+// formatVerboseSection renders the raw burned amount, gas price and sender of
+// the burn transaction, the implementation if the token is a proxy, and any
+// configured extra LP views.
+func (d *LPBurnDetector) formatVerboseSection(ctx context.Context, tx *types.Transaction, value *big.Int, lpAddress, token common.Address) string {
+	msg := d.messages
+	sender := msg.Unknown
+	if from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); err == nil {
+		label := from.Hex()
+		if d.config.ENSLookup {
+			label = html.EscapeString(d.displayAddress(ctx, from))
 		}
-		topHolders = strings.Join(holderStrings, "|")
+		sender = fmt.Sprintf("<a href=\"%s/address/%s\">%s</a>", d.config.ExplorerURL, from.Hex(), label)
+	} else {
+		logf(ctx, "Failed to recover tx sender: %v", err)
 	}
 
-	message := fmt.Sprintf(`🔥🔥New LP Burn Detected🔥🔥
-<a href="https://etherscan.io/address/%s">%s</a><b>(%s)</b>
-<code>%s</code>
+	gasPrice := msg.Unknown
+	if tx.GasPrice() != nil {
+		gwei := newFloat().Quo(floatFromInt(tx.GasPrice()), floatFrom(1e9))
+		gasPrice = gwei.Text('f', 2) + " gwei"
+	}
 
-💰<b>Mcap:</b> $%s
-        <b>⎿ Hash:</b> <a href="https://etherscan.io/tx/%s">Click Here</a>
-        <b>⎿ Burned:</b> %.1f(%.2f%%)
+	implementation := ""
+	if impl, ok := d.proxyImplementation(ctx, token); ok {
+		implementation = fmt.Sprintf("\n        <b>⎿ %s:</b> <a href=\"%s/address/%s\">%s</a>", msg.Implementation, d.config.ExplorerURL, impl.Hex(), impl.Hex())
+	}
 
-🔵 Honeypot : %s
-        <b>⎿ Buy Tax:</b> %s
-        <b>⎿ Sell Tax:</b> %s
-        <b>⎿ Clogged:</b> %s (%.1f%%)
+	extraViews := ""
+	for _, method := range d.config.LPExtraViews {
+		values, err := d.callView(ctx, lpAddress, method)
+		if err != nil {
+			logf(ctx, "Failed to read %s from LP %s: %v", method, lpAddress.Hex(), err)
+			continue
+		}
+		extraViews += fmt.Sprintf("\n        <b>⎿ %s:</b> <code>%s</code>", html.EscapeString(method), html.EscapeString(fmt.Sprint(values...)))
+	}
 
-👤 Current Holders Count: %s
-        <b>⎿ Top Holders:</b> %s
+	return fmt.Sprintf(`
 
-<b>Chart:</b> <a href="https://www.dextools.io/app/en/ether/pair-explorer/%s">DexTools</a> | <a href="https://dexscreener.com/ethereum/%s">DexScreener</a> | <a href="https://dexspy.io/eth/token/%s">DexSpy</a>
-<b>Snipe:</b> <a href="https://t.me/MaestroSniperBot?start=%s">Maestro</a> (<a href="https://t.me/MaestroProBot?start=%s">Pro</a>) | <a href="https://t.me/BananaGunSniper_bot?start=snp_Atasya_%s">Banana</a>
-<b>More Tools:</b> <a href="https://t.me/GenApes">100x at GenApes</a>`,
-		tokenContract.Hex(), details.TokenName, details.TokenSymbol, tokenContract.Hex(),
-		formatNumber(priceData.Mcap), txHash.Hex(), burnedFormatted, percentageFormatted,
-		honeypotStatus, buyTax, sellTax, formatNumber(int64(cloggedFormatted)), cloggedPercentageFormatted,
-		details.HolderCount, topHolders,
-		tokenContract.Hex(), tokenContract.Hex(), tokenContract.Hex(),
-		tokenContract.Hex(), tokenContract.Hex(), tokenContract.Hex())
+🧾 <b>%s</b>
+        <b>⎿ %s:</b> <code>%s</code>
+        <b>⎿ %s:</b> %s
+        <b>⎿ %s:</b> %s%s`, msg.RawTransaction, msg.BurnedWei, value.String(), msg.GasPrice, gasPrice, msg.Sender, sender, implementation+extraViews)
+}
 
-	return d.sendTelegramMessage(message)
+// alertFooter renders the configured footer line, linked when a footer URL
+// is set, or nothing when the footer is disabled.
+func (d *LPBurnDetector) alertFooter() string {
+	if !d.config.FooterEnabled || d.config.FooterText == "" {
+		return ""
+	}
+	text := html.EscapeString(d.config.FooterText)
+	if d.config.FooterURL != "" {
+		text = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(d.config.FooterURL), text)
+	}
+	return "\n" + text
+}
+
+// formatTopHolders renders the n largest holders, by share, as explorer
+// links.
+func (d *LPBurnDetector) formatTopHolders(ctx context.Context, holders []Holder, n int) []string {
+	type share struct {
+		address string
+		percent float64
+	}
+	shares := make([]share, 0, len(holders))
+	for _, holder := range holders {
+		percent, _ := strconv.ParseFloat(holder.Percent, 64)
+		shares = append(shares, share{holder.Address, percent})
+	}
+	sort.SliceStable(shares, func(i, j int) bool {
+		return shares[i].percent > shares[j].percent
+	})
+	if len(shares) > n {
+		shares = shares[:n]
+	}
+
+	holderStrings := make([]string, 0, len(shares))
+	for _, s := range shares {
+		link := fmt.Sprintf("%s/address/%s", d.config.ExplorerURL, s.address)
+		if d.config.ENSLookup {
+			label := d.displayAddress(ctx, common.HexToAddress(s.address))
+			holderStrings = append(holderStrings, fmt.Sprintf("<a href=\"%s\">%s</a> %.4f%%", link, html.EscapeString(label), s.percent))
+			continue
+		}
+		holderStrings = append(holderStrings, fmt.Sprintf("<a href=\"%s\">%.4f%%</a>", link, s.percent))
+	}
+	return holderStrings
+}
+
+// selectProjectToken picks the side of the pair the alert is about. Configured
+// overrides win; otherwise it's whichever token is not a quote token (WETH or
+// a stable). ok is false when both sides are quote tokens: a pool between two
+// majors has no project token.
+func (d *LPBurnDetector) selectProjectToken(lpAddress, token0, token1 common.Address) (token common.Address, ok bool) {
+	if override, ok := d.config.PoolProjectTokens[strings.ToLower(lpAddress.Hex())]; ok {
+		switch common.HexToAddress(override) {
+		case token0:
+			return token0, true
+		case token1:
+			return token1, true
+		default:
+			log.Printf("Project token override %s is not in pool %s, ignoring", override, lpAddress.Hex())
+		}
+	}
+
+	if d.projectTokens.Contains(token0) {
+		return token0, true
+	}
+	if d.projectTokens.Contains(token1) {
+		return token1, true
+	}
+
+	quote0, quote1 := d.isQuoteToken(token0), d.isQuoteToken(token1)
+	switch {
+	case quote0 && quote1:
+		return common.Address{}, false
+	case quote0:
+		return token1, true
+	default:
+		return token0, true
+	}
+}
+
+func (d *LPBurnDetector) isQuoteToken(token common.Address) bool {
+	return token == d.weth || d.stableAddrs.Contains(token)
+}
+
+// isHighTax reports whether a buy or sell tax (a fraction, 0.3 = 30%) is
+// above the configured safe maximum.
+func (d *LPBurnDetector) isHighTax(tax float64) bool {
+	return d.config.MaxSafeTax > 0 && tax > d.config.MaxSafeTax
+}
+
+// burnedLPPercent sums the share of the LP that GoPlus reports as held by
+// the dead or zero address, to corroborate the on-chain burn figure.
+func (d *LPBurnDetector) burnedLPPercent(holders []Holder) float64 {
+	var burned float64
+	for _, holder := range holders {
+		addr := common.HexToAddress(holder.Address)
+		if !d.isDeadAddress(addr) && addr != (common.Address{}) {
+			continue
+		}
+		if percent, err := strconv.ParseFloat(holder.Percent, 64); err == nil {
+			burned += percent * 100
+		}
+	}
+	return burned
+}
+
+// taxUnknown reports whether a GoPlus tax value is missing. GoPlus (and the
+// fallback details) use "0" when the tax wasn't determined.
+func taxUnknown(tax string) bool {
+	return tax == "" || tax == "0"
+}
+
+// lpBurnShare scales a burned LP amount by the LP's decimals and works out
+// what percentage of supply it is.
+func lpBurnShare(value, supply *big.Int, decimals uint8) (burned, percent *big.Float) {
+	divisor := floatFromInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	burned = newFloat().Quo(floatFromInt(value), divisor)
+
+	percent = newFloat()
+	if supply.Sign() > 0 {
+		percent.Quo(floatFromInt(value), floatFromInt(supply))
+		percent.Mul(percent, floatFrom(100))
+	}
+	return burned, percent
 }
 
 func formatNumber(num int64) string {
@@ -708,58 +1515,305 @@ func formatNumber(num int64) string {
 	return result.String()
 }
 
-func (d *LPBurnDetector) watchLogs() {
-	// Create transfer event filter for dead address
-	transferTopic := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
-	deadAddress := common.HexToAddress(DEAD_ADDR)
-
-	query := ethereum.FilterQuery{
-		Topics: [][]common.Hash{
-			{transferTopic},
-			{}, // from (any address)
-			{common.BytesToHash(deadAddress.Bytes())}, // to (dead address)
-		},
+// watchLogs subscribes to the node and processes logs until a subscription
+// fails. batcher outlives it so burns pending at a reconnect aren't lost.
+func (d *LPBurnDetector) watchLogs(batcher *logBatcher) error {
+	if err := d.catchUp(context.Background()); err != nil {
+		log.Printf("❌ Catch-up failed, starting live: %v", err)
 	}
 
+	// One subscription per watched event type, all feeding logs
 	logs := make(chan types.Log)
-	sub, err := d.client.SubscribeFilterLogs(context.Background(), query, logs)
+	subErrs := make(chan error, 1)
+	for _, query := range d.watchQueries() {
+		sub, err := d.client.SubscribeFilterLogs(context.Background(), query, logs)
+		if err != nil {
+			return fmt.Errorf("%w to logs: %v", ErrSubscribe, err)
+		}
+		defer sub.Unsubscribe()
+		go func() {
+			if err, ok := <-sub.Err(); ok {
+				select {
+				case subErrs <- err:
+				default:
+				}
+			}
+		}()
+	}
+
+	// Liquidity additions come from a second subscription; its channels
+	// stay nil, and so never fire, when the feature is off
+	var addLogs chan types.Log
+	var addSubErr <-chan error
+	if d.config.WatchLiquidityAdds {
+		addLogs = make(chan types.Log)
+		addSub, err := d.client.SubscribeFilterLogs(context.Background(), mintFilterQuery(), addLogs)
+		if err != nil {
+			return fmt.Errorf("%w to mint logs: %v", ErrSubscribe, err)
+		}
+		defer addSub.Unsubscribe()
+		addSubErr = addSub.Err()
+	}
+
+	// New heads keep the chain head current even when no watched event
+	// shows up for a while
+	heads := make(chan *types.Header)
+	headSub, err := d.client.SubscribeNewHead(context.Background(), heads)
 	if err != nil {
-		log.Fatalf("Failed to subscribe to logs: %v", err)
+		return fmt.Errorf("%w to new heads: %v", ErrSubscribe, err)
 	}
+	defer headSub.Unsubscribe()
+
+	d.stats.connected.Store(true)
 
 	log.Println("🔍 Starting LP burn detector...")
-	log.Println("📡 Listening for transfer events to dead address...")
+	log.Printf("📡 Listening for %s events...", strings.Join(d.config.WatchEvents, ", "))
+	if d.config.WatchLiquidityAdds {
+		log.Println("💧 Also listening for liquidity additions...")
+	}
+	if d.fromAddrs.Len() > 0 {
+		log.Printf("👀 Only watching burns from %d watchlisted address(es)", d.fromAddrs.Len())
+	}
+	if d.supplyWatchPairs.Len() > 0 {
+		log.Printf("📉 Polling supply of %d watchlisted pair(s) every block", d.supplyWatchPairs.Len())
+	}
+
+	// Logs arrive per event, so progress is reported once a block is done:
+	// when the first log of a later block shows up.
+	var lastBlock uint64
+	var blockTransfers int
 
 	for {
 		select {
-		case err := <-sub.Err():
-			log.Printf("❌ Subscription error: %v", err)
-			return
+		case err := <-subErrs:
+			d.stats.connected.Store(false)
+			return fmt.Errorf("subscription error: %v", err)
+		case err := <-addSubErr:
+			d.stats.connected.Store(false)
+			return fmt.Errorf("mint subscription error: %v", err)
+		case err := <-headSub.Err():
+			d.stats.connected.Store(false)
+			return fmt.Errorf("new head subscription error: %v", err)
+		case header := <-heads:
+			d.stats.setHead(header.Number.Uint64(), d.clock.Now())
+			d.observeHead(header)
+			if d.supplyWatchPairs.Len() > 0 {
+				go d.pollSupplies(header.Number)
+			}
+		case vLog := <-addLogs:
+			d.enqueueLiquidityAdd(vLog)
 		case vLog := <-logs:
-			// Log the current block being scanned
-			log.Printf("🔍 Scanning block %d for LP burns...", vLog.BlockNumber)
+			if vLog.BlockNumber != lastBlock {
+				if lastBlock != 0 {
+					log.Printf("🔍 Scanned block %d: %d watched event(s)", lastBlock, blockTransfers)
+					if err := d.cursor.Save(lastBlock); err != nil {
+						log.Printf("Failed to save block cursor: %v", err)
+					}
+				}
+				lastBlock = vLog.BlockNumber
+				blockTransfers = 0
+				d.stats.blocks.Add(1)
+			}
+			blockTransfers++
+			if d.admitLog(vLog) {
+				batcher.add(vLog)
+			}
+		case batch := <-batcher.out:
+			if d.processed.Has(batch[0].TxHash) {
+				d.stats.skips.Add(1)
+				d.skips.log(skipf("tx %s already processed", batch[0].TxHash.Hex()))
+				continue
+			}
+			d.enqueueBatch(batch)
+		}
+	}
+}
 
-			log.Printf("📝 Found transfer to dead address in tx: %s", vLog.TxHash.Hex())
+// handleLiquidityAdd runs a Mint log through processLiquidityAdd.
+func (d *LPBurnDetector) handleLiquidityAdd(vLog types.Log) {
+	ctx, cancel := d.newEventContext()
+	err := d.processLiquidityAdd(ctx, vLog)
+	cancel()
+	if isSkip(err) {
+		d.stats.skips.Add(1)
+		d.skips.log(err)
+	} else if err != nil {
+		logf(ctx, "❌ Failed to process liquidity add in tx %s: %v", vLog.TxHash.Hex(), err)
+	} else {
+		logf(ctx, "💧 Liquidity addition detected and alert queued!")
+	}
+}
 
-			err := d.processLPBurn(vLog.TxHash)
-			if err != nil {
-				log.Printf("❌ Not an LP burn: %v", err)
-			} else {
-				log.Printf("🔥 LP burn detected and message sent!")
-			}
+// admitLog counts a watched log and reports whether it should be batched
+// for processing.
+func (d *LPBurnDetector) admitLog(vLog types.Log) bool {
+	d.stats.transfers.Add(1)
+
+	// Spam contracts emit zero-value transfers to the dead address;
+	// drop them before spending any RPC calls
+	if d.config.DropZeroValue && isZeroValueTransfer(vLog) {
+		d.stats.skips.Add(1)
+		d.skips.log(skipf("zero-value transfer from %s in tx %s", vLog.Address.Hex(), vLog.TxHash.Hex()))
+		return false
+	}
+	if d.config.MinBurnPercent > 0 && d.isDustBurn(vLog) {
+		d.stats.skips.Add(1)
+		d.skips.log(skipf("dust burn from %s in tx %s", vLog.Address.Hex(), vLog.TxHash.Hex()))
+		return false
+	}
+
+	log.Printf("📝 Found %s in tx: %s", eventName(vLog), vLog.TxHash.Hex())
+	return true
+}
+
+// processBatch runs one tx's batched logs through processLPBurn.
+func (d *LPBurnDetector) processBatch(batch []types.Log) {
+	txHash := batch[0].TxHash
+	blockNumber := new(big.Int).SetUint64(batch[0].BlockNumber)
+
+	ctx, cancel := d.newEventContext()
+	defer cancel()
+	if batch = d.filterMinBurn(ctx, batch); len(batch) == 0 {
+		return
+	}
+	err := d.processLPBurn(ctx, txHash, blockNumber, batch)
+	if errors.Is(err, context.DeadlineExceeded) {
+		logf(ctx, "⏱️ Processing tx %s timed out after %s: %v", txHash.Hex(), d.config.ProcessTimeout, err)
+	} else if isSkip(err) {
+		d.stats.skips.Add(1)
+		d.skips.log(err)
+	} else if err != nil {
+		logf(ctx, "❌ Failed to process tx %s: %v", txHash.Hex(), err)
+	} else {
+		d.stats.burns.Add(1)
+		logf(ctx, "🔥 LP burn detected and alert queued!")
+		d.markProcessed(ctx, txHash)
+	}
+}
+
+// filterMinBurn drops the batch's transfers that burn less than
+// MinBurnPercent of their LP.
+func (d *LPBurnDetector) filterMinBurn(ctx context.Context, batch []types.Log) []types.Log {
+	if d.config.MinBurnPercent <= 0 {
+		return batch
+	}
+	kept := batch[:0:0]
+	for _, vLog := range batch {
+		if below, percent := d.belowMinBurn(ctx, vLog); below {
+			d.stats.skips.Add(1)
+			d.skips.log(skipf("burn of %.4f%% of %s LP below minimum %.2f%% in tx %s", percent, vLog.Address.Hex(), d.config.MinBurnPercent, vLog.TxHash.Hex()))
+			continue
 		}
+		kept = append(kept, vLog)
+	}
+	return kept
+}
+
+// markProcessed records that txHash was alerted on; dry runs don't count.
+func (d *LPBurnDetector) markProcessed(ctx context.Context, txHash common.Hash) {
+	if d.config.DryRun {
+		return
+	}
+	if err := d.processed.Add(txHash); err != nil {
+		logf(ctx, "Failed to record processed tx: %v", err)
 	}
 }
 
 func main() {
-	detector, err := NewLPBurnDetector()
+	printConfig := flag.Bool("print-config", false, "print the effective configuration as JSON and exit")
+	printVersion := flag.Bool("version", false, "print version and build information and exit")
+	flag.Parse()
+
+	if *printVersion {
+		fmt.Println(currentBuildInfo())
+		return
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	if *printConfig {
+		out := effectiveConfig(cfg)
+		out["BotToken"] = "[redacted]"
+		out["ChatID"] = CHAT_ID
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			log.Fatalf("Failed to print config: %v", err)
+		}
+		return
+	}
+
+	if cfg.ASCIIMode {
+		log.SetOutput(asciiWriter{os.Stderr})
+	}
+	if cfg.InstanceName != "" {
+		log.SetPrefix("[" + cfg.InstanceName + "] ")
+	}
+
+	if cfg.BacktestFile != "" {
+		cfg.DryRun = true
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	detector, err := NewLPBurnDetector(ctx, cfg, realClock{})
 	if err != nil {
 		log.Fatalf("Failed to create LP burn detector: %v", err)
 	}
 
-	log.Println("🚀 LP Burn Detector started")
+	if cfg.BacktestFile != "" {
+		if err := detector.runBacktest(cfg.BacktestFile, os.Stdout); err != nil {
+			log.Fatalf("Backtest failed: %v", err)
+		}
+		return
+	}
+
+	log.Printf("🚀 LP Burn Detector started, version %s", currentBuildInfo())
 	log.Println("🔗 Connected to Ethereum node")
 	log.Println("📱 Telegram bot configured")
 
-	detector.watchLogs()
+	go detector.telegram.run(ctx)
+	if detector.slack != nil {
+		go detector.slack.run(ctx)
+	}
+	if detector.webhook != nil {
+		go detector.webhook.run(ctx)
+	}
+	if cfg.CanaryToken != "" {
+		go detector.runCanary(ctx)
+	}
+	if detector.digest != nil {
+		go detector.runDigest(ctx)
+	}
+	if detector.projectTokens.Len() > 0 {
+		go detector.runMetadataPrefetch(ctx)
+	}
+	if cfg.GeckoSupported {
+		go detector.quotePrices.run(ctx)
+	}
+	go detector.skips.run(cfg.SkipSummaryInterval)
+	if cfg.HeartbeatInterval > 0 {
+		go detector.runHeartbeat(cfg.HeartbeatInterval)
+	}
+	if cfg.HTTPAddr != "" {
+		go detector.serveHTTP(cfg.HTTPAddr)
+	}
+
+	if cfg.ReplayFile != "" {
+		go func() {
+			if err := detector.runReplay(cfg.ReplayFile); err != nil {
+				log.Printf("❌ Replay failed: %v", err)
+			}
+		}()
+	} else {
+		detector.runWorkers(ctx)
+		go detector.runWatcher(ctx)
+	}
+
+	<-ctx.Done()
+	log.Println("🛑 Shutting down...")
 }
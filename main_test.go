@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestLPBurnShare(t *testing.T) {
+	e := func(n int64, exp int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(n), new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil))
+	}
+	tests := []struct {
+		name        string
+		value       *big.Int
+		supply      *big.Int
+		decimals    uint8
+		wantBurned  float64
+		wantPercent float64
+	}{
+		{"18 decimals, full burn", e(5, 18), e(5, 18), 18, 5, 100},
+		{"18 decimals, partial burn", e(1, 18), e(4, 18), 18, 1, 25},
+		{"6 decimals, partial burn", e(250, 6), e(1000, 6), 6, 250, 25},
+		{"9 decimals, small burn", e(3, 9), e(1200, 9), 9, 3, 0.25},
+		{"empty supply", e(1, 18), big.NewInt(0), 18, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			burned, percent := lpBurnShare(tt.value, tt.supply, tt.decimals)
+			if got, _ := burned.Float64(); got != tt.wantBurned {
+				t.Errorf("burned = %v, want %v", got, tt.wantBurned)
+			}
+			if got, _ := percent.Float64(); got != tt.wantPercent {
+				t.Errorf("percent = %v, want %v", got, tt.wantPercent)
+			}
+		})
+	}
+}
+
+func TestGetTokenBalanceEmptyResult(t *testing.T) {
+	d, node := newTestDetector(t, nil)
+	node.handleCall(func(to common.Address, input []byte) ([]byte, error) {
+		return nil, nil
+	})
+
+	token := common.HexToAddress(testToken)
+	_, err := d.getTokenBalance(context.Background(), token, token, nil)
+	if !errors.Is(err, ErrReverted) {
+		t.Errorf("err = %v, want %v", err, ErrReverted)
+	}
+}
+
+func TestGetTokenBalanceRevert(t *testing.T) {
+	d, node := newTestDetector(t, nil)
+	node.handleCall(func(to common.Address, input []byte) ([]byte, error) {
+		return nil, errors.New("execution reverted")
+	})
+
+	token := common.HexToAddress(testToken)
+	_, err := d.getTokenBalance(context.Background(), token, token, nil)
+	if !errors.Is(err, ErrReverted) {
+		t.Errorf("err = %v, want %v", err, ErrReverted)
+	}
+}
+
+// stubPool answers the ERC20 and pair views analyzeLPBurn reads for a
+// token paired with WETH. The token's own balanceOf fails with
+// tokenBalanceErr when it's set.
+type stubPool struct {
+	abi             abi.ABI
+	lp, token, weth common.Address
+	tokenBalanceErr error
+}
+
+func (p *stubPool) call(to common.Address, input []byte) ([]byte, error) {
+	method, err := p.abi.MethodById(input[:4])
+	if err != nil {
+		return nil, err
+	}
+	e := func(n, exp int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(n), new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil))
+	}
+
+	var out interface{}
+	switch to {
+	case p.lp:
+		out = map[string]interface{}{
+			"name":        "Uniswap V2",
+			"symbol":      "UNI-V2",
+			"decimals":    uint8(18),
+			"totalSupply": e(100, 18),
+			"token0":      p.token,
+			"token1":      p.weth,
+		}[method.Name]
+	case p.token:
+		out = map[string]interface{}{
+			"name":        "Test Token",
+			"symbol":      "TST",
+			"decimals":    uint8(9),
+			"totalSupply": e(1_000_000_000, 9),
+		}[method.Name]
+		if method.Name == "balanceOf" {
+			args, err := method.Inputs.Unpack(input[4:])
+			if err != nil {
+				return nil, err
+			}
+			switch args[0].(common.Address) {
+			case p.token:
+				if p.tokenBalanceErr != nil {
+					return nil, p.tokenBalanceErr
+				}
+				out = e(5_000_000, 9)
+			case p.lp:
+				out = e(500_000_000, 9)
+			}
+		}
+	case p.weth:
+		out = map[string]interface{}{"symbol": "WETH", "decimals": uint8(18)}[method.Name]
+	}
+	if out == nil {
+		return nil, errors.New("execution reverted")
+	}
+	return method.Outputs.Pack(out)
+}
+
+func TestAlertCloggedLine(t *testing.T) {
+	tests := []struct {
+		name            string
+		tokenBalanceErr error
+		wantClogged     bool
+	}{
+		{"balance known", nil, true},
+		{"balanceOf reverts", errors.New("execution reverted"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, node := newTestDetector(t, nil)
+			d.config.RecentAddLookback = 0
+			pool := &stubPool{
+				abi:             d.contractABI,
+				lp:              common.HexToAddress("0x2222222222222222222222222222222222222222"),
+				token:           common.HexToAddress(testToken),
+				weth:            d.weth,
+				tokenBalanceErr: tt.tokenBalanceErr,
+			}
+			node.handleCall(pool.call)
+
+			tx := types.NewTx(&types.LegacyTx{To: &pool.lp, Gas: 100000, GasPrice: big.NewInt(1)})
+			value := new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil)
+			result, err := d.analyzeLPBurn(context.Background(), tx, pool.lp, value, big.NewInt(100), lpBurn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Contains(result.Message, d.messages.Clogged+":"); got != tt.wantClogged {
+				t.Errorf("clogged line shown = %v, want %v:\n%s", got, tt.wantClogged, result.Message)
+			}
+		})
+	}
+}
+
+func TestAlertLinksFollowConfig(t *testing.T) {
+	d, node := newTestDetector(t, nil)
+	d.config.RecentAddLookback = 0
+	d.config.VerboseAlerts = true
+	d.config.ExplorerURL = "https://basescan.org"
+	d.config.DexToolsChain = "base"
+	d.config.DexScreenerChain = "base"
+	d.config.DexSpyChain = "base"
+	pool := &stubPool{
+		abi:   d.contractABI,
+		lp:    common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		token: common.HexToAddress(testToken),
+		weth:  d.weth,
+	}
+	node.handleCall(pool.call)
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(8453)), &types.LegacyTx{To: &pool.lp, Gas: 100000, GasPrice: big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	value := new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil)
+	result, err := d.analyzeLPBurn(context.Background(), tx, pool.lp, value, big.NewInt(100), lpBurn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"https://basescan.org/address/" + pool.token.Hex(),
+		"https://basescan.org/tx/" + tx.Hash().Hex(),
+		"https://basescan.org/address/" + sender.Hex(),
+		"https://www.dextools.io/app/en/base/pair-explorer/" + pool.token.Hex(),
+		"https://dexscreener.com/base/" + pool.token.Hex(),
+		"https://dexspy.io/base/token/" + pool.token.Hex(),
+	} {
+		if !strings.Contains(result.Message, want) {
+			t.Errorf("alert is missing %s:\n%s", want, result.Message)
+		}
+	}
+	if strings.Contains(result.Message, "etherscan.io") {
+		t.Errorf("alert still links to etherscan:\n%s", result.Message)
+	}
+}
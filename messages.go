@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Messages holds every human-readable label used in alerts. A locale file
+// (<LocaleDir>/<Language>.json) overrides any subset of them; labels it
+// leaves out stay English.
+type Messages struct {
+	NewBurn            string `json:"new_burn"`
+	LiquidityAdded     string `json:"liquidity_added"`
+	LiquidityRemovedV2 string `json:"liquidity_removed_v2"`
+	LiquidityRemovedV3 string `json:"liquidity_removed_v3"`
+	HighTax            string `json:"high_tax"`
+	Over               string `json:"over"`
+	RecentLPOnly       string `json:"recent_lp_only"`
+	SupplyDrop         string `json:"supply_drop"`
+	Digest             string `json:"digest"`
+	Resent             string `json:"resent"`
+
+	Mcap            string `json:"mcap"`
+	Hash            string `json:"hash"`
+	ClickHere       string `json:"click_here"`
+	Burned          string `json:"burned"`
+	BurnMethod      string `json:"burn_method"`
+	Added           string `json:"added"`
+	Removed         string `json:"removed"`
+	Liquidity       string `json:"liquidity"`
+	LPHolders       string `json:"lp_holders"`
+	BurnedPerGoPlus string `json:"burned_per_goplus"`
+	SupplyInLP      string `json:"supply_in_lp"`
+	Pair            string `json:"pair"`
+	DEX             string `json:"dex"`
+	Links           string `json:"links"`
+	Block           string `json:"block"`
+	UnknownDEX      string `json:"unknown_dex"`
+
+	Risk        string `json:"risk"`
+	RiskSafe    string `json:"risk_safe"`
+	RiskCaution string `json:"risk_caution"`
+	RiskDanger  string `json:"risk_danger"`
+	Honeypot    string `json:"honeypot"`
+	BuyTax      string `json:"buy_tax"`
+	SellTax     string `json:"sell_tax"`
+	Clogged     string `json:"clogged"`
+	Approximate string `json:"approximate"`
+	OpenSource  string `json:"open_source"`
+	Proxy       string `json:"proxy"`
+	HolderCount string `json:"holder_count"`
+	TopHolders  string `json:"top_holders"`
+
+	Chart string `json:"chart"`
+	Snipe string `json:"snipe"`
+
+	RawTransaction string `json:"raw_transaction"`
+	BurnedWei      string `json:"burned_wei"`
+	GasPrice       string `json:"gas_price"`
+	Sender         string `json:"sender"`
+	Implementation string `json:"implementation"`
+
+	Unknown      string `json:"unknown"`
+	True         string `json:"true"`
+	False        string `json:"false"`
+	NotAvailable string `json:"not_available"`
+	Updated      string `json:"updated"`
+	More         string `json:"more"`
+}
+
+var englishMessages = Messages{
+	NewBurn:            "🔥🔥New LP Burn Detected🔥🔥",
+	LiquidityAdded:     "💧 Liquidity Added",
+	LiquidityRemovedV2: "🚨 Liquidity Removed (V2)",
+	LiquidityRemovedV3: "🚨 Liquidity Removed (V3)",
+	HighTax:            "HIGH TAX",
+	Over:               "over",
+	RecentLPOnly:       "Burned only recently-added LP",
+	SupplyDrop:         "📉 LP Supply Dropped",
+	Digest:             "📋 LP Burn Digest",
+	Resent:             "re-sent",
+
+	Mcap:            "Mcap",
+	Hash:            "Hash",
+	ClickHere:       "Click Here",
+	Burned:          "Burned",
+	BurnMethod:      "Burn method",
+	Added:           "Added",
+	Removed:         "Removed",
+	Liquidity:       "liquidity",
+	LPHolders:       "LP Holders after burn",
+	BurnedPerGoPlus: "burned per GoPlus",
+	SupplyInLP:      "Supply in LP",
+	Pair:            "Pair",
+	DEX:             "DEX",
+	Links:           "Links",
+	Block:           "Block",
+	UnknownDEX:      "Unknown DEX",
+
+	Risk:        "Risk",
+	RiskSafe:    "SAFE",
+	RiskCaution: "CAUTION",
+	RiskDanger:  "DANGER",
+	Honeypot:    "Honeypot",
+	BuyTax:      "Buy Tax",
+	SellTax:     "Sell Tax",
+	Clogged:     "Clogged",
+	Approximate: "approx., decimals unknown",
+	OpenSource:  "Open Source",
+	Proxy:       "Proxy",
+	HolderCount: "Current Holders Count",
+	TopHolders:  "Top Holders",
+
+	Chart: "Chart",
+	Snipe: "Snipe",
+
+	RawTransaction: "Raw Transaction",
+	BurnedWei:      "Burned (wei)",
+	GasPrice:       "Gas Price",
+	Sender:         "Sender",
+	Implementation: "Implementation",
+
+	Unknown:      "Unknown",
+	True:         "True",
+	False:        "False",
+	NotAvailable: "N/A",
+	Updated:      "updated",
+	More:         "more",
+}
+
+// LoadMessages returns the catalog for language. English is built in; any
+// other language needs a locale file in dir.
+func LoadMessages(language, dir string) (*Messages, error) {
+	messages := englishMessages
+
+	path := filepath.Join(dir, language+".json")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && language == "en" {
+		return &messages, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read locale file: %v", err)
+	}
+
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("invalid locale file %s: %v", path, err)
+	}
+	return &messages, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// metricLabels renders the given name/value pairs as a Prometheus label set,
+// adding instance_name when an instance name is configured.
+func (d *LPBurnDetector) metricLabels(pairs ...string) string {
+	if d.config.InstanceName != "" {
+		pairs = append([]string{"instance_name", d.config.InstanceName}, pairs...)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	labels := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels = append(labels, fmt.Sprintf("%s=%q", pairs[i], pairs[i+1]))
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+// handleMetrics serves GET /metrics in the Prometheus text format.
+func (d *LPBurnDetector) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	connected := 0
+	if d.stats.connected.Load() {
+		connected = 1
+	}
+	fmt.Fprintln(w, "# HELP burn_detector_connected Whether the log subscription to the node is up.")
+	fmt.Fprintln(w, "# TYPE burn_detector_connected gauge")
+	fmt.Fprintf(w, "burn_detector_connected%s %d\n", d.metricLabels(), connected)
+
+	if block, age, ok := d.stats.chainHead(d.clock.Now()); ok {
+		fmt.Fprintln(w, "# HELP burn_detector_head_block Latest block from the new-heads subscription.")
+		fmt.Fprintln(w, "# TYPE burn_detector_head_block gauge")
+		fmt.Fprintf(w, "burn_detector_head_block%s %d\n", d.metricLabels(), block)
+		fmt.Fprintln(w, "# HELP burn_detector_head_age_seconds Time since the latest new head arrived.")
+		fmt.Fprintln(w, "# TYPE burn_detector_head_age_seconds gauge")
+		fmt.Fprintf(w, "burn_detector_head_age_seconds%s %.0f\n", d.metricLabels(), age.Seconds())
+	}
+
+	fmt.Fprintln(w, "# HELP burn_detector_subscription_failures Subscription failures since the connection was last stable.")
+	fmt.Fprintln(w, "# TYPE burn_detector_subscription_failures gauge")
+	fmt.Fprintf(w, "burn_detector_subscription_failures%s %d\n", d.metricLabels(), d.reconnects.consecutive.Load())
+
+	if d.config.CanaryToken != "" && d.canary.runs.Load() > 0 {
+		ok := 1
+		if d.canary.failing.Load() {
+			ok = 0
+		}
+		fmt.Fprintln(w, "# HELP burn_detector_canary_ok Whether the last canary check of the external APIs passed.")
+		fmt.Fprintln(w, "# TYPE burn_detector_canary_ok gauge")
+		fmt.Fprintf(w, "burn_detector_canary_ok%s %d\n", d.metricLabels(), ok)
+		fmt.Fprintln(w, "# HELP burn_detector_canary_failures_total Failed canary checks.")
+		fmt.Fprintln(w, "# TYPE burn_detector_canary_failures_total counter")
+		fmt.Fprintf(w, "burn_detector_canary_failures_total%s %d\n", d.metricLabels(), d.canary.failures.Load())
+	}
+
+	fmt.Fprintln(w, "# HELP burn_detector_work_queue_depth Tx batches and liquidity adds waiting for a worker.")
+	fmt.Fprintln(w, "# TYPE burn_detector_work_queue_depth gauge")
+	fmt.Fprintf(w, "burn_detector_work_queue_depth%s %d\n", d.metricLabels(), d.work.depth())
+	fmt.Fprintln(w, "# HELP burn_detector_work_queue_dropped_total Tx batches and liquidity adds dropped because the work queue was full.")
+	fmt.Fprintln(w, "# TYPE burn_detector_work_queue_dropped_total counter")
+	fmt.Fprintf(w, "burn_detector_work_queue_dropped_total%s %d\n", d.metricLabels(), d.work.dropped.Load())
+
+	fmt.Fprintln(w, "# HELP burn_detector_breaker_state Circuit breaker state per provider (0 closed, 1 half-open, 2 open).")
+	fmt.Fprintln(w, "# TYPE burn_detector_breaker_state gauge")
+	names := make([]string, 0, len(d.breakers))
+	for name := range d.breakers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "burn_detector_breaker_state%s %d\n", d.metricLabels("provider", name), d.breakers[name].State())
+	}
+
+	fmt.Fprintln(w, "# HELP burn_detector_price_source_total Burns priced by each price source.")
+	fmt.Fprintln(w, "# TYPE burn_detector_price_source_total counter")
+	sources, counts := d.priceSources.snapshot()
+	for _, source := range sources {
+		fmt.Fprintf(w, "burn_detector_price_source_total%s %d\n", d.metricLabels("source", source), counts[source])
+	}
+
+	prices := d.quotePrices.Snapshot()
+	tokens := make([]string, 0, len(prices))
+	for token := range prices {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+
+	fmt.Fprintln(w, "# HELP burn_detector_quote_price_usd Cached USD price of each quote token.")
+	fmt.Fprintln(w, "# TYPE burn_detector_quote_price_usd gauge")
+	for _, token := range tokens {
+		fmt.Fprintf(w, "burn_detector_quote_price_usd%s %g\n", d.metricLabels("token", token), prices[token].USD)
+	}
+	fmt.Fprintln(w, "# HELP burn_detector_quote_price_age_seconds Age of each cached quote token price.")
+	fmt.Fprintln(w, "# TYPE burn_detector_quote_price_age_seconds gauge")
+	for _, token := range tokens {
+		fmt.Fprintf(w, "burn_detector_quote_price_age_seconds%s %.0f\n", d.metricLabels("token", token), d.clock.Now().Sub(prices[token].FetchedAt).Seconds())
+	}
+}
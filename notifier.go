@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// Notifier is a destination for burn alerts. Every configured notifier
+// receives every alert.
+type Notifier interface {
+	Name() string
+	Notify(alert Alert) error
+}
+
+func (d *LPBurnDetector) notify(alert Alert) {
+	if d.resyncing() {
+		log.Printf("🔇 Suppressed alert during node resync:\n%s", stripHTML(alert.Text))
+		return
+	}
+	if d.config.InstanceName != "" {
+		alert.Text = fmt.Sprintf("<b>[%s]</b> %s", html.EscapeString(d.config.InstanceName), alert.Text)
+	}
+	if d.config.ASCIIAlerts {
+		alert.Text = toASCII(alert.Text)
+	}
+
+	for _, n := range d.notifiers {
+		if err := n.Notify(alert); err != nil {
+			log.Printf("❌ Failed to notify %s: %v", n.Name(), err)
+		}
+	}
+}
+
+// ConsoleNotifier echoes a plain-text rendering of each alert to a writer,
+// normally stdout.
+type ConsoleNotifier struct {
+	mu    sync.Mutex
+	out   io.Writer
+	clock Clock
+}
+
+func NewConsoleNotifier(out io.Writer, clock Clock) *ConsoleNotifier {
+	return &ConsoleNotifier{out: out, clock: clock}
+}
+
+func (c *ConsoleNotifier) Name() string {
+	return "console"
+}
+
+func (c *ConsoleNotifier) Notify(alert Alert) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err := fmt.Fprintf(c.out, "----- %s -----\n%s\n\n", c.clock.Now().Format(time.RFC3339), stripHTML(alert.Text))
+	return err
+}
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// stripHTML removes the Telegram HTML markup from an alert, leaving the
+// visible text.
+func stripHTML(s string) string {
+	return html.UnescapeString(htmlTagPattern.ReplaceAllString(s, ""))
+}
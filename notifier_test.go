@@ -0,0 +1,20 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestConsoleNotifierUsesClock(t *testing.T) {
+	var out bytes.Buffer
+	clock := newFakeClock()
+	c := NewConsoleNotifier(&out, clock)
+
+	if err := c.Notify(Alert{Text: "<b>New burn</b> &amp; more"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "----- 2025-01-01T00:00:00Z -----\nNew burn & more\n\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const PAIR_ABI = `[
+	{
+		"constant": true,
+		"inputs": [],
+		"name": "factory",
+		"outputs": [{"name": "", "type": "address"}],
+		"type": "function"
+	},
+	{
+		"constant": true,
+		"inputs": [],
+		"name": "getReserves",
+		"outputs": [
+			{"name": "_reserve0", "type": "uint112"},
+			{"name": "_reserve1", "type": "uint112"},
+			{"name": "_blockTimestampLast", "type": "uint32"}
+		],
+		"type": "function"
+	}
+]`
+
+const V3_POOL_ABI = `[
+	{
+		"inputs": [],
+		"name": "slot0",
+		"outputs": [
+			{"name": "sqrtPriceX96", "type": "uint160"},
+			{"name": "tick", "type": "int24"},
+			{"name": "observationIndex", "type": "uint16"},
+			{"name": "observationCardinality", "type": "uint16"},
+			{"name": "observationCardinalityNext", "type": "uint16"},
+			{"name": "feeProtocol", "type": "uint8"},
+			{"name": "unlocked", "type": "bool"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+const CHAINLINK_ABI = `[
+	{
+		"inputs": [],
+		"name": "decimals",
+		"outputs": [{"name": "", "type": "uint8"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "latestRoundData",
+		"outputs": [
+			{"name": "roundId", "type": "uint80"},
+			{"name": "answer", "type": "int256"},
+			{"name": "startedAt", "type": "uint256"},
+			{"name": "updatedAt", "type": "uint256"},
+			{"name": "answeredInRound", "type": "uint80"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+func (d *LPBurnDetector) getReserves(ctx context.Context, lpAddress common.Address, blockNumber *big.Int) (*big.Int, *big.Int, error) {
+	data, err := d.pairABI.Pack("getReserves")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := d.callContract(ctx, ethereum.CallMsg{
+		To:   &lpAddress,
+		Data: data,
+		Gas:  d.config.CallGasLimit,
+	}, blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values, err := d.pairABI.Unpack("getReserves", result)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(values) < 2 {
+		return nil, nil, fmt.Errorf("unexpected getReserves result")
+	}
+
+	reserve0, ok0 := values[0].(*big.Int)
+	reserve1, ok1 := values[1].(*big.Int)
+	if !ok0 || !ok1 {
+		return nil, nil, fmt.Errorf("unexpected getReserves result types")
+	}
+
+	return reserve0, reserve1, nil
+}
+
+// getSqrtPriceX96 reads the current sqrt price from a Uniswap V3 pool.
+func (d *LPBurnDetector) getSqrtPriceX96(ctx context.Context, poolAddress common.Address, blockNumber *big.Int) (*big.Int, error) {
+	data, err := d.v3PoolABI.Pack("slot0")
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.callContract(ctx, ethereum.CallMsg{
+		To:   &poolAddress,
+		Data: data,
+		Gas:  d.config.CallGasLimit,
+	}, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := d.v3PoolABI.Unpack("slot0", result)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) < 1 {
+		return nil, fmt.Errorf("unexpected slot0 result")
+	}
+
+	sqrtPriceX96, ok := values[0].(*big.Int)
+	if !ok || sqrtPriceX96.Sign() == 0 {
+		return nil, fmt.Errorf("pool is not initialized")
+	}
+	return sqrtPriceX96, nil
+}
+
+// v3Price converts a V3 pool's sqrtPriceX96 into whole quote tokens per whole
+// project token. The pool price is token1 per token0 in raw units:
+// (sqrtPriceX96 / 2^96)^2, scaled by 10^(decimals0 - decimals1).
+func v3Price(sqrtPriceX96 *big.Int, decimals0, decimals1 uint8, projectIsToken0 bool) *big.Float {
+	sqrtPrice := floatFromInt(sqrtPriceX96)
+	sqrtPrice.Quo(sqrtPrice, floatFromInt(new(big.Int).Lsh(big.NewInt(1), 96)))
+
+	price := newFloat().Mul(sqrtPrice, sqrtPrice)
+	price.Mul(price, floatFromInt(pow10(decimals0)))
+	price.Quo(price, floatFromInt(pow10(decimals1)))
+
+	if projectIsToken0 {
+		return price
+	}
+	return newFloat().Quo(floatFrom(1), price)
+}
+
+// getOraclePrice reads a Chainlink-style aggregator's latest answer.
+func (d *LPBurnDetector) getOraclePrice(ctx context.Context, oracle common.Address) (float64, error) {
+	call := func(method string) ([]interface{}, error) {
+		data, err := d.chainlinkABI.Pack(method)
+		if err != nil {
+			return nil, err
+		}
+		result, err := d.client.CallContract(ctx, ethereum.CallMsg{
+			To:   &oracle,
+			Data: data,
+			Gas:  d.config.CallGasLimit,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		return d.chainlinkABI.Unpack(method, result)
+	}
+
+	decimalsOut, err := call("decimals")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read oracle decimals: %v", err)
+	}
+	roundOut, err := call("latestRoundData")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read oracle answer: %v", err)
+	}
+	if len(decimalsOut) < 1 || len(roundOut) < 2 {
+		return 0, fmt.Errorf("unexpected oracle result")
+	}
+
+	decimals, ok := decimalsOut[0].(uint8)
+	answer, ok2 := roundOut[1].(*big.Int)
+	if !ok || !ok2 || answer.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid oracle answer")
+	}
+
+	price, _ := newFloat().Quo(floatFromInt(answer), floatFromInt(pow10(decimals))).Float64()
+	return price, nil
+}
+
+// quoteTokenPriceUSD returns the USD price of a pool's quote token for the
+// on-chain fallback. For the wrapped native token a configured static price
+// or oracle takes precedence over the GeckoTerminal cache, so chains the
+// price APIs don't cover still work. Stables fall back to $1.
+func (d *LPBurnDetector) quoteTokenPriceUSD(ctx context.Context, token common.Address) (float64, error) {
+	if token == d.weth {
+		if d.config.QuotePriceUSD > 0 {
+			return d.config.QuotePriceUSD, nil
+		}
+		if d.config.QuotePriceOracle != "" {
+			return d.getOraclePrice(ctx, common.HexToAddress(d.config.QuotePriceOracle))
+		}
+	}
+
+	if price, _, ok := d.quotePrices.Get(token); ok {
+		return price, nil
+	}
+
+	if d.stableAddrs.Contains(token) {
+		return 1, nil
+	}
+
+	return 0, fmt.Errorf("no USD price for quote token %s", token.Hex())
+}
+
+// quoteTokenDecimals returns a quote token's decimals, preferring the
+// configured QuoteDecimals so USDC-style 6-decimal reserves are scaled
+// right even when decimals() can't be read.
+func (d *LPBurnDetector) quoteTokenDecimals(ctx context.Context, token common.Address, blockNumber *big.Int) (uint8, error) {
+	if decimals, ok := d.config.QuoteDecimals[strings.ToLower(token.Hex())]; ok {
+		return decimals, nil
+	}
+	return d.getTokenDecimals(ctx, token, blockNumber)
+}
+
+// getOnChainPriceData prices the project token from the pool (V2 reserves or
+// V3 slot0) and the quote token's USD price. It is the fallback when GeckoTerminal has no
+// data for the pool.
+func (d *LPBurnDetector) getOnChainPriceData(ctx context.Context, lpAddress, token0, token1, projectToken common.Address, blockNumber *big.Int) (*PriceData, error) {
+	quoteToken := token1
+	if projectToken == token1 {
+		quoteToken = token0
+	}
+
+	quoteUSD, err := d.quoteTokenPriceUSD(ctx, quoteToken)
+	if err != nil {
+		return nil, err
+	}
+
+	projectDecimals, err := d.getTokenDecimals(ctx, projectToken, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project token decimals: %v", err)
+	}
+	quoteDecimals, err := d.quoteTokenDecimals(ctx, quoteToken, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quote token decimals: %v", err)
+	}
+
+	supply, err := d.getTokenSupply(ctx, projectToken, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token supply: %v", err)
+	}
+
+	// V2 pairs expose getReserves; V3 pools don't, and are priced from
+	// slot0 with the pool's quote token balance standing in for its reserve.
+	var quotePerProject, quoteAmount *big.Float
+	if reserve0, reserve1, err := d.getReserves(ctx, lpAddress, blockNumber); err == nil {
+		projectReserve, quoteReserve := reserve0, reserve1
+		if projectToken == token1 {
+			projectReserve, quoteReserve = reserve1, reserve0
+		}
+		if projectReserve.Sign() == 0 {
+			return nil, fmt.Errorf("pool has no project token reserve")
+		}
+		quoteAmount = scaleAmount(quoteReserve, quoteDecimals)
+		quotePerProject = newFloat().Quo(quoteAmount, scaleAmount(projectReserve, projectDecimals))
+	} else {
+		sqrtPriceX96, v3Err := d.getSqrtPriceX96(ctx, lpAddress, blockNumber)
+		if v3Err != nil {
+			return nil, fmt.Errorf("failed to get reserves: %v; failed to get slot0: %v", err, v3Err)
+		}
+		quoteBalance, err := d.getTokenBalance(ctx, quoteToken, lpAddress, blockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pool quote balance: %v", err)
+		}
+
+		decimals0, decimals1 := projectDecimals, quoteDecimals
+		if projectToken == token1 {
+			decimals0, decimals1 = quoteDecimals, projectDecimals
+		}
+		quoteAmount = scaleAmount(quoteBalance, quoteDecimals)
+		quotePerProject = v3Price(sqrtPriceX96, decimals0, decimals1, projectToken == token0)
+	}
+
+	// price = quote per project token * quote USD
+	price := newFloat().Mul(quotePerProject, floatFrom(quoteUSD))
+
+	mcapFloat := newFloat().Mul(price, scaleAmount(supply, projectDecimals))
+	mcap, _ := mcapFloat.Int64()
+
+	// Assume equal value on both sides; exact for V2, an approximation for
+	// a V3 pool's in-range liquidity
+	liquidity := newFloat().Mul(quoteAmount, floatFrom(2*quoteUSD))
+	liquidityUSD, _ := liquidity.Float64()
+
+	priceFloat, _ := price.Float64()
+	return &PriceData{
+		Price:        fmt.Sprintf("%.9f", priceFloat),
+		Mcap:         mcap,
+		LiquidityUSD: liquidityUSD,
+	}, nil
+}
+
+func pow10(decimals uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
+
+// scaleAmount converts a raw token amount to whole units.
+func scaleAmount(amount *big.Int, decimals uint8) *big.Float {
+	return newFloat().Quo(floatFromInt(amount), floatFromInt(pow10(decimals)))
+}
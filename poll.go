@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	SubscriptionAuto = "auto"
+	SubscriptionWS   = "ws"
+	SubscriptionPoll = "poll"
+)
+
+// ErrSubscribe marks a failure to set up a node subscription, as opposed to
+// one that failed later; in auto mode it switches to polling.
+var ErrSubscribe = errors.New("failed to subscribe")
+
+func isWebSocketURL(url string) bool {
+	url = strings.ToLower(url)
+	return strings.HasPrefix(url, "ws://") || strings.HasPrefix(url, "wss://")
+}
+
+// initialSubscriptionMode resolves auto to ws for a WebSocket node URL and
+// to poll for anything else.
+func initialSubscriptionMode(configured, nodeURL string) string {
+	if configured != SubscriptionAuto {
+		return configured
+	}
+	if isWebSocketURL(nodeURL) {
+		return SubscriptionWS
+	}
+	return SubscriptionPoll
+}
+
+// subscriptionMode is the mode the watcher is running in, ws or poll.
+func (d *LPBurnDetector) subscriptionMode() string {
+	mode, _ := d.mode.Load().(string)
+	return mode
+}
+
+// watch runs one watcher session in the current subscription mode. In auto
+// mode a WebSocket subscription that can't be set up switches to polling
+// for good.
+func (d *LPBurnDetector) watch(batcher *logBatcher) error {
+	if d.subscriptionMode() == SubscriptionPoll {
+		return d.pollLogs(batcher)
+	}
+
+	err := d.watchLogs(batcher)
+	if d.config.SubscriptionMode == SubscriptionAuto && errors.Is(err, ErrSubscribe) {
+		log.Printf("⚠️ %v; falling back to polling every %s", err, d.config.PollInterval)
+		d.mode.Store(SubscriptionPoll)
+	}
+	return err
+}
+
+// pollLogs watches for logs with eth_getLogs every PollInterval, for nodes
+// without WebSocket subscriptions, until a poll fails.
+func (d *LPBurnDetector) pollLogs(batcher *logBatcher) error {
+	if err := d.catchUp(context.Background()); err != nil {
+		log.Printf("❌ Catch-up failed, starting live: %v", err)
+	}
+
+	last, err := d.client.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get head block: %v", err)
+	}
+
+	d.stats.connected.Store(true)
+
+	log.Println("🔍 Starting LP burn detector...")
+	log.Printf("📡 Polling for %s events every %s...", strings.Join(d.config.WatchEvents, ", "), d.config.PollInterval)
+	if d.config.WatchLiquidityAdds {
+		log.Println("💧 Also polling for liquidity additions...")
+	}
+
+	// The timer is only re-armed after a poll, so batches arriving in
+	// between don't push the next poll back
+	nextPoll := d.clock.After(d.config.PollInterval)
+	for {
+		select {
+		case <-nextPoll:
+			head, err := d.pollOnce(batcher, last)
+			if err != nil {
+				d.stats.connected.Store(false)
+				return err
+			}
+			last = head
+			nextPoll = d.clock.After(d.config.PollInterval)
+		case batch := <-batcher.out:
+			if d.processed.Has(batch[0].TxHash) {
+				d.stats.skips.Add(1)
+				d.skips.log(skipf("tx %s already processed", batch[0].TxHash.Hex()))
+				continue
+			}
+			d.enqueueBatch(batch)
+		}
+	}
+}
+
+// pollOnce fetches the watched logs in the blocks after last up to the
+// current head and returns the head.
+func (d *LPBurnDetector) pollOnce(batcher *logBatcher, last uint64) (uint64, error) {
+	ctx := context.Background()
+	header, err := d.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return last, fmt.Errorf("failed to get head block: %v", err)
+	}
+	head := header.Number.Uint64()
+	d.stats.setHead(head, d.clock.Now())
+	d.observeHead(header)
+	if head <= last {
+		return last, nil
+	}
+
+	for from := last + 1; from <= head; from += catchUpChunk {
+		to := from + catchUpChunk - 1
+		if to > head {
+			to = head
+		}
+		fromBlock, toBlock := new(big.Int).SetUint64(from), new(big.Int).SetUint64(to)
+
+		var logs []types.Log
+		for _, query := range d.watchQueries() {
+			query.FromBlock, query.ToBlock = fromBlock, toBlock
+			chunk, err := d.client.FilterLogs(ctx, query)
+			if err != nil {
+				return last, fmt.Errorf("failed to get logs for blocks %d-%d: %v", from, to, err)
+			}
+			logs = append(logs, chunk...)
+		}
+		sort.SliceStable(logs, func(i, j int) bool {
+			if logs[i].BlockNumber != logs[j].BlockNumber {
+				return logs[i].BlockNumber < logs[j].BlockNumber
+			}
+			return logs[i].Index < logs[j].Index
+		})
+		for _, vLog := range logs {
+			if d.admitLog(vLog) {
+				batcher.add(vLog)
+			}
+		}
+
+		if d.config.WatchLiquidityAdds {
+			query := mintFilterQuery()
+			query.FromBlock, query.ToBlock = fromBlock, toBlock
+			mints, err := d.client.FilterLogs(ctx, query)
+			if err != nil {
+				return last, fmt.Errorf("failed to get mint logs for blocks %d-%d: %v", from, to, err)
+			}
+			for _, vLog := range mints {
+				d.enqueueLiquidityAdd(vLog)
+			}
+		}
+
+		log.Printf("🔍 Scanned blocks %d-%d: %d watched event(s)", from, to, len(logs))
+		d.stats.blocks.Add(int64(to - from + 1))
+		if err := d.cursor.Save(to); err != nil {
+			log.Printf("Failed to save block cursor: %v", err)
+		}
+		last = to
+	}
+
+	if d.supplyWatchPairs.Len() > 0 {
+		go d.pollSupplies(header.Number)
+	}
+	return head, nil
+}
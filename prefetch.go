@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/errgroup"
+)
+
+// metadataCache holds prefetched name/symbol/logo metadata for watchlist
+// tokens (ProjectTokens), so their alerts skip the metadata lookup.
+type metadataCache struct {
+	mu      sync.RWMutex
+	entries map[common.Address]metadataEntry
+}
+
+type metadataEntry struct {
+	details   *TokenDetails
+	fetchedAt time.Time
+}
+
+func newMetadataCache() *metadataCache {
+	return &metadataCache{entries: make(map[common.Address]metadataEntry)}
+}
+
+func (c *metadataCache) get(token common.Address) (*TokenDetails, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[token]
+	return entry.details, ok
+}
+
+func (c *metadataCache) set(token common.Address, details *TokenDetails, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[token] = metadataEntry{details: details, fetchedAt: now}
+}
+
+// stale reports whether token has no entry or one older than maxAge.
+func (c *metadataCache) stale(token common.Address, now time.Time, maxAge time.Duration) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[token]
+	return !ok || now.Sub(entry.fetchedAt) >= maxAge
+}
+
+// runMetadataPrefetch fetches metadata and decimals for every watchlist
+// token at startup, then refreshes stale entries every
+// MetadataRefreshInterval until ctx is cancelled.
+func (d *LPBurnDetector) runMetadataPrefetch(ctx context.Context) {
+	for {
+		d.prefetchMetadata(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.clock.After(d.config.MetadataRefreshInterval):
+		}
+	}
+}
+
+func (d *LPBurnDetector) prefetchMetadata(ctx context.Context) {
+	now := d.clock.Now()
+	var g errgroup.Group
+	g.SetLimit(d.config.PrefetchConcurrency)
+
+	fetched := 0
+	var mu sync.Mutex
+	for token := range d.projectTokens {
+		if !d.metadata.stale(token, now, d.config.MetadataRefreshInterval) {
+			continue
+		}
+		g.Go(func() error {
+			fetchCtx, cancel := context.WithTimeout(ctx, d.config.EnrichTimeout)
+			defer cancel()
+
+			address := strings.ToLower(token.Hex())
+			details, err := d.getMetadata(fetchCtx, address)
+			if err != nil {
+				log.Printf("Failed to prefetch metadata for %s: %v", token.Hex(), err)
+				return nil
+			}
+			if _, err := d.getTokenDecimals(fetchCtx, token, nil); err != nil {
+				log.Printf("Failed to prefetch decimals for %s: %v", token.Hex(), err)
+			}
+			d.metadata.set(token, details, d.clock.Now())
+
+			mu.Lock()
+			fetched++
+			mu.Unlock()
+			return nil
+		})
+	}
+	g.Wait()
+
+	if fetched > 0 {
+		log.Printf("📇 Prefetched metadata for %d watchlist token(s)", fetched)
+	}
+}
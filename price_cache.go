@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type GeckoTokenPriceResponse struct {
+	Data struct {
+		Attributes struct {
+			TokenPrices map[string]string `json:"token_prices"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+type QuotePrice struct {
+	USD       float64   `json:"usd"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// quotePriceCache keeps the USD prices of the quote tokens (WETH and the
+// major stables) warm so the market-cap fallback doesn't fetch them per burn.
+type quotePriceCache struct {
+	mu         sync.RWMutex
+	prices     map[common.Address]QuotePrice
+	tokens     []common.Address
+	network    string
+	ttl        time.Duration
+	httpClient *http.Client
+
+	// breaker is GeckoTerminal's, shared with the pool lookups since both
+	// hit the same API.
+	breaker     *CircuitBreaker
+	maxAttempts int
+	baseDelay   time.Duration
+
+	clock Clock
+}
+
+func newQuotePriceCache(httpClient *http.Client, network string, ttl time.Duration, tokens []common.Address, breaker *CircuitBreaker, maxAttempts int, baseDelay time.Duration, clock Clock) *quotePriceCache {
+	return &quotePriceCache{
+		prices:      make(map[common.Address]QuotePrice),
+		tokens:      tokens,
+		network:     network,
+		ttl:         ttl,
+		httpClient:  httpClient,
+		breaker:     breaker,
+		maxAttempts: maxAttempts,
+		baseDelay:   baseDelay,
+		clock:       clock,
+	}
+}
+
+// Get returns the cached USD price of token and how old it is. ok is false
+// when no price has been fetched yet.
+func (c *quotePriceCache) Get(token common.Address) (price float64, age time.Duration, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	p, ok := c.prices[token]
+	if !ok {
+		return 0, 0, false
+	}
+	return p.USD, c.clock.Now().Sub(p.FetchedAt), true
+}
+
+// Snapshot returns a copy of all cached prices keyed by token address.
+func (c *quotePriceCache) Snapshot() map[string]QuotePrice {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	snapshot := make(map[string]QuotePrice, len(c.prices))
+	for token, p := range c.prices {
+		snapshot[strings.ToLower(token.Hex())] = p
+	}
+	return snapshot
+}
+
+func (c *quotePriceCache) refresh(ctx context.Context) error {
+	addrs := make([]string, len(c.tokens))
+	for i, token := range c.tokens {
+		addrs[i] = strings.ToLower(token.Hex())
+	}
+
+	reqURL := fmt.Sprintf("https://api.geckoterminal.com/api/v2/simple/networks/%s/token_price/%s", c.network, strings.Join(addrs, ","))
+
+	var body []byte
+	err := callProvider(ctx, c.breaker, c.clock, "GeckoTerminal", c.maxAttempts, c.baseDelay, func() error {
+		var err error
+		body, err = httpDo(ctx, c.httpClient, "GET", reqURL, map[string]string{"Accept": "application/json"}, nil)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("gecko token price API error: %w", err)
+	}
+
+	var result GeckoTokenPriceResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return err
+	}
+
+	now := c.clock.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for addr, raw := range result.Data.Attributes.TokenPrices {
+		price, err := strconv.ParseFloat(raw, 64)
+		if err != nil || price <= 0 {
+			continue
+		}
+		c.prices[common.HexToAddress(addr)] = QuotePrice{USD: price, FetchedAt: now}
+	}
+
+	return nil
+}
+
+// run refreshes the cache immediately and then once per TTL until ctx is
+// cancelled.
+func (c *quotePriceCache) run(ctx context.Context) {
+	for {
+		if err := c.refresh(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to refresh quote token prices: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.clock.After(c.ttl):
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	priceSourceGecko       = "geckoterminal"
+	priceSourceDexScreener = "dexscreener"
+	priceSourceOnChain     = "onchain"
+)
+
+// priceSourceCounts counts which source priced each burn, for metrics.
+type priceSourceCounts struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (c *priceSourceCounts) add(source string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[string]int64)
+	}
+	c.counts[source]++
+}
+
+// snapshot returns the sources seen so far, sorted, and their counts.
+func (c *priceSourceCounts) snapshot() ([]string, map[string]int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := make(map[string]int64, len(c.counts))
+	sources := make([]string, 0, len(c.counts))
+	for source, n := range c.counts {
+		counts[source] = n
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources, counts
+}
+
+// getPriceWithFallback prices the project token from GeckoTerminal, then
+// DexScreener, then the pool itself, returning the first that works with
+// its Source set.
+func (d *LPBurnDetector) getPriceWithFallback(ctx context.Context, lpAddress, token0, token1, projectToken common.Address, blockNumber *big.Int) (*PriceData, error) {
+	type source struct {
+		name string
+		get  func() (*PriceData, error)
+	}
+	var sources []source
+	if d.config.GeckoSupported {
+		sources = append(sources, source{priceSourceGecko, func() (*PriceData, error) {
+			priceData, err := d.getPriceData(ctx, lpAddress.Hex(), geckoBaseToken(token0, projectToken))
+			if err != nil {
+				return nil, err
+			}
+			return d.checkMcapSanity(ctx, priceData, lpAddress, token0, token1, projectToken, blockNumber), nil
+		}})
+	}
+	sources = append(sources,
+		source{priceSourceDexScreener, func() (*PriceData, error) {
+			return d.getDexScreenerPriceData(ctx, lpAddress, projectToken)
+		}},
+		source{priceSourceOnChain, func() (*PriceData, error) {
+			return d.getOnChainPriceData(ctx, lpAddress, token0, token1, projectToken, blockNumber)
+		}},
+	)
+
+	var errs []string
+	for _, s := range sources {
+		priceData, err := s.get()
+		if err != nil {
+			logf(ctx, "Failed to get %s price data: %v", s.name, err)
+			errs = append(errs, fmt.Sprintf("%s: %v", s.name, err))
+			continue
+		}
+		if priceData.Source == "" {
+			priceData.Source = s.name
+		}
+		d.priceSources.add(priceData.Source)
+		return priceData, nil
+	}
+	return nil, fmt.Errorf("all price sources failed: %s", strings.Join(errs, "; "))
+}
+
+// geckoBaseToken is the GeckoTerminal base_token index that prices
+// projectToken: 0 when it is the pool's token0, otherwise 1.
+func geckoBaseToken(token0, projectToken common.Address) int {
+	if projectToken == token0 {
+		return 0
+	}
+	return 1
+}
+
+// getDexScreenerPriceData prices the project token from DexScreener's entry
+// for the pool. DexScreener quotes the pair's base token, so the project
+// token must be the base.
+func (d *LPBurnDetector) getDexScreenerPriceData(ctx context.Context, lpAddress, projectToken common.Address) (*PriceData, error) {
+	pairs, err := d.getDexScreenerPairs(ctx, projectToken.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pair := range pairs {
+		if !strings.EqualFold(pair.PairAddress, lpAddress.Hex()) {
+			continue
+		}
+		if !strings.EqualFold(pair.BaseToken.Address, projectToken.Hex()) {
+			return nil, fmt.Errorf("pool %s quotes %s, not the project token", lpAddress.Hex(), pair.BaseToken.Symbol)
+		}
+
+		price, err := strconv.ParseFloat(pair.PriceUsd, 64)
+		if err != nil || price <= 0 {
+			return nil, fmt.Errorf("no USD price for pool %s", lpAddress.Hex())
+		}
+
+		supply, err := d.getTokenSupply(ctx, projectToken, nil)
+		if err != nil {
+			return nil, err
+		}
+		decimals, err := d.getTokenDecimals(ctx, projectToken, nil)
+		if err != nil {
+			return nil, err
+		}
+		divisor := floatFromInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+		parsedSupply := newFloat().Quo(floatFromInt(supply), divisor)
+		mcap, _ := newFloat().Mul(floatFrom(price), parsedSupply).Int64()
+
+		return &PriceData{
+			Price:        fmt.Sprintf("%.9f", price),
+			Mcap:         mcap,
+			LiquidityUSD: pair.Liquidity.USD,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("pool %s not listed", lpAddress.Hex())
+}
+
+// checkMcapSanity guards against GeckoTerminal indexing glitches that put a
+// price orders of magnitude off. When gecko's market cap and the pool's
+// on-chain estimate differ by more than McapSanityRatio, Gecko is asked
+// again up to McapSanityRetries times; if it still disagrees the on-chain
+// figure is used. Without an on-chain estimate gecko is trusted.
+func (d *LPBurnDetector) checkMcapSanity(ctx context.Context, gecko *PriceData, lpAddress, token0, token1, projectToken common.Address, blockNumber *big.Int) *PriceData {
+	if d.config.McapSanityRatio <= 0 || gecko.Mcap <= 0 {
+		return gecko
+	}
+
+	onChain, err := d.getOnChainPriceData(ctx, lpAddress, token0, token1, projectToken, blockNumber)
+	if err != nil || onChain.Mcap <= 0 {
+		return gecko
+	}
+
+	for attempt := 0; ; attempt++ {
+		ratio := float64(gecko.Mcap) / float64(onChain.Mcap)
+		if ratio < 1 {
+			ratio = 1 / ratio
+		}
+		if ratio <= d.config.McapSanityRatio {
+			return gecko
+		}
+		if attempt == d.config.McapSanityRetries {
+			logf(ctx, "⚠️ GeckoTerminal mcap $%s for %s is %.0fx the on-chain $%s, using on-chain",
+				formatNumber(gecko.Mcap), lpAddress.Hex(), ratio, formatNumber(onChain.Mcap))
+			onChain.Source = priceSourceOnChain
+			return onChain
+		}
+
+		logf(ctx, "GeckoTerminal mcap $%s for %s is %.0fx the on-chain $%s, refetching",
+			formatNumber(gecko.Mcap), lpAddress.Hex(), ratio, formatNumber(onChain.Mcap))
+		retried, err := d.getPriceData(ctx, lpAddress.Hex(), geckoBaseToken(token0, projectToken))
+		if err != nil || retried.Mcap <= 0 {
+			continue
+		}
+		gecko = retried
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestGeckoBaseToken(t *testing.T) {
+	token := common.HexToAddress(testToken)
+	weth := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+
+	tests := []struct {
+		name           string
+		token0, token1 common.Address
+		want           int
+	}{
+		{"project token is token0", token, weth, 0},
+		{"project token is token1", weth, token, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := geckoBaseToken(tt.token0, token); got != tt.want {
+				t.Errorf("geckoBaseToken(%s, %s) = %d, want %d", tt.token0.Hex(), token.Hex(), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuotePriceCacheRunStopsOnCancel(t *testing.T) {
+	d, _ := newTestDetector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"attributes":{"token_prices":{}}}}`))
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.quotePrices.run(ctx)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("run did not return after its context was cancelled")
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// eip1967ImplementationSlot is bytes32(uint256(keccak256("eip1967.proxy.implementation")) - 1).
+var eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// proxyCache remembers each token's EIP-1967 implementation; the zero
+// address records that a token isn't a proxy.
+type proxyCache struct {
+	mu    sync.RWMutex
+	impls map[common.Address]common.Address
+}
+
+func newProxyCache() *proxyCache {
+	return &proxyCache{impls: make(map[common.Address]common.Address)}
+}
+
+func (c *proxyCache) get(token common.Address) (common.Address, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	impl, ok := c.impls[token]
+	return impl, ok
+}
+
+func (c *proxyCache) set(token, impl common.Address) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.impls[token] = impl
+}
+
+// proxyImplementation returns the implementation behind an EIP-1967 proxy.
+// ok is false when token isn't a proxy or the slot can't be read.
+func (d *LPBurnDetector) proxyImplementation(ctx context.Context, token common.Address) (common.Address, bool) {
+	impl, cached := d.proxies.get(token)
+	if !cached {
+		slot, err := d.client.StorageAt(ctx, token, eip1967ImplementationSlot, nil)
+		if err != nil {
+			return common.Address{}, false
+		}
+		impl = common.BytesToAddress(slot)
+		d.proxies.set(token, impl)
+	}
+	return impl, impl != (common.Address{}) && impl != token
+}
+
+// callMetadata calls a metadata view (name, decimals, ...) on token. If the
+// call fails and token is an EIP-1967 proxy, it is retried against the
+// implementation, where constant metadata often still answers.
+func (d *LPBurnDetector) callMetadata(ctx context.Context, token common.Address, method string, blockNumber *big.Int) ([]byte, error) {
+	data, err := d.contractABI.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.callContract(ctx, ethereum.CallMsg{
+		To:   &token,
+		Data: data,
+		Gas:  d.config.CallGasLimit,
+	}, blockNumber)
+	if err == nil {
+		return result, nil
+	}
+
+	impl, ok := d.proxyImplementation(ctx, token)
+	if !ok {
+		return nil, err
+	}
+	result, implErr := d.callContract(ctx, ethereum.CallMsg{
+		To:   &impl,
+		Data: data,
+		Gas:  d.config.CallGasLimit,
+	}, blockNumber)
+	if implErr != nil {
+		return nil, err
+	}
+	return result, nil
+}
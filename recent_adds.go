@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// recentlyMintedLP sums the LP tokens the pair minted in the
+// RecentAddLookback blocks up to blockNumber.
+func (d *LPBurnDetector) recentlyMintedLP(ctx context.Context, lpAddress common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if blockNumber == nil {
+		head, _, ok := d.stats.chainHead(d.clock.Now())
+		if !ok {
+			var err error
+			if head, err = d.client.BlockNumber(ctx); err != nil {
+				return nil, err
+			}
+		}
+		blockNumber = new(big.Int).SetUint64(head)
+	}
+
+	from := new(big.Int).Sub(blockNumber, new(big.Int).SetUint64(d.config.RecentAddLookback))
+	if from.Sign() < 0 {
+		from.SetInt64(0)
+	}
+
+	logs, err := d.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: from,
+		ToBlock:   blockNumber,
+		Addresses: []common.Address{lpAddress},
+		Topics: [][]common.Hash{
+			{transferEventTopic},
+			{addressTopic(common.Address{})},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The first mint locks MINIMUM_LIQUIDITY to the zero address; skip it
+	minted := new(big.Int)
+	for _, vLog := range logs {
+		if len(vLog.Topics) != 3 || len(vLog.Data) != 32 || topicAddress(vLog.Topics[2]) == (common.Address{}) {
+			continue
+		}
+		minted.Add(minted, new(big.Int).SetBytes(vLog.Data))
+	}
+	return minted, nil
+}
+
+// burnedOnlyRecentLP flags the rug pattern of adding fresh liquidity and
+// burning just that, while the rest of the pool's LP stays in the deployer's
+// hands: the burn is covered by recent mints and there is older LP left.
+func (d *LPBurnDetector) burnedOnlyRecentLP(ctx context.Context, lpAddress common.Address, burned, lpSupply, blockNumber *big.Int) bool {
+	minted, err := d.recentlyMintedLP(ctx, lpAddress, blockNumber)
+	if err != nil {
+		logf(ctx, "Failed to look up recent liquidity adds: %v", err)
+		return false
+	}
+	if minted.Sign() == 0 {
+		return false
+	}
+
+	// Allow 1% slack for rounding between what was minted and burned
+	slack := new(big.Int).Div(minted, big.NewInt(100))
+	covered := new(big.Int).Add(minted, slack)
+	return burned.Cmp(covered) <= 0 && lpSupply.Cmp(covered) > 0
+}
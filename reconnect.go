@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// reconnectTracker counts subscription failures since the connection was
+// last stable, so a flaky provider can be alerted on before burns are missed.
+type reconnectTracker struct {
+	consecutive atomic.Int64
+
+	mu       sync.Mutex
+	failures []time.Time
+	alerted  bool
+}
+
+// failure records a failure and reports whether threshold failures have now
+// happened within window. It fires once until the next reset.
+func (t *reconnectTracker) failure(now time.Time, window time.Duration, threshold int) bool {
+	t.consecutive.Add(1)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.failures = append(t.failures, now)
+	for len(t.failures) > 0 && now.Sub(t.failures[0]) > window {
+		t.failures = t.failures[1:]
+	}
+
+	if threshold > 0 && !t.alerted && len(t.failures) >= threshold {
+		t.alerted = true
+		return true
+	}
+	return false
+}
+
+func (t *reconnectTracker) reset() {
+	t.consecutive.Store(0)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failures = nil
+	t.alerted = false
+}
+
+// runWatcher runs the watcher, resubscribing after every failure, until ctx
+// is cancelled.
+func (d *LPBurnDetector) runWatcher(ctx context.Context) {
+	batcher := newLogBatcher(d.config.BurnFlushWindow, d.clock)
+	d.mode.Store(initialSubscriptionMode(d.config.SubscriptionMode, NODE_URL))
+	log.Printf("📡 Subscription mode: %s (configured %s)", d.subscriptionMode(), d.config.SubscriptionMode)
+
+	for {
+		stopped := make(chan struct{})
+		go func() {
+			select {
+			case <-d.clock.After(d.config.StableUptime):
+			case <-stopped:
+				return
+			}
+			if d.reconnects.consecutive.Load() > 0 {
+				log.Printf("✅ Subscription stable for %s, resetting failure count", d.config.StableUptime)
+			}
+			d.reconnects.reset()
+		}()
+		err := d.watch(batcher)
+		close(stopped)
+
+		log.Printf("❌ %v; reconnecting in %s", err, d.config.ReconnectDelay)
+		if d.reconnects.failure(d.clock.Now(), d.config.ReconnectAlertWindow, d.config.ReconnectAlertThreshold) {
+			d.alertAdmin(ctx, fmt.Sprintf("⚠️ <b>Node subscription unstable</b>\n%d failures within %s\nLast error: %v",
+				d.config.ReconnectAlertThreshold, d.config.ReconnectAlertWindow, html.EscapeString(err.Error())))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.clock.After(d.config.ReconnectDelay):
+		}
+	}
+}
+
+// alertAdmin sends an operational alert to the admin chat, if configured.
+func (d *LPBurnDetector) alertAdmin(ctx context.Context, text string) {
+	log.Printf("🚨 %s", text)
+	if d.config.AdminChatID == "" {
+		return
+	}
+
+	if d.config.InstanceName != "" {
+		text = fmt.Sprintf("<b>[%s]</b> %s", html.EscapeString(d.config.InstanceName), text)
+	}
+	sendCtx, cancel := context.WithTimeout(ctx, d.config.TelegramTimeout)
+	defer cancel()
+	if _, err := d.telegram.sendToChat(sendCtx, d.config.AdminChatID, text, 0); err != nil {
+		log.Printf("❌ Failed to send admin alert: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Find returns the records whose event ID or tx hash is id; a tx that
+// burned several LP tokens has one record per token.
+func (s *BurnStore) Find(id string) []BurnRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matched []BurnRecord
+	for _, rec := range s.records {
+		if rec.EventID == id || strings.EqualFold(rec.TxHash, id) {
+			matched = append(matched, rec)
+		}
+	}
+	return matched
+}
+
+// resendBurn re-sends the alert for the stored burn(s) with the given event
+// ID or tx hash. The message is rendered from the stored records only, so
+// it shows the figures as they were at detection time.
+func (d *LPBurnDetector) resendBurn(id string) (int, error) {
+	records := d.store.Find(id)
+	if len(records) == 0 {
+		return 0, fmt.Errorf("no stored burn %q: %w", id, ErrNotFound)
+	}
+
+	var messages []string
+	var mcap int64
+	for _, rec := range records {
+		messages = append(messages, d.formatStoredBurn(rec))
+		if rec.Mcap > mcap {
+			mcap = rec.Mcap
+		}
+	}
+
+	ctx := withEventID(context.Background(), records[0].EventID)
+	alert := Alert{Text: d.withAlertID(ctx, strings.Join(messages, "\n\n━━━━━━━━━━━━━━━\n\n")), Mcap: mcap}
+	if len(records) == 1 {
+		alert.Thread = strings.ToLower(records[0].LPAddress)
+	}
+	logf(ctx, "♻️ Re-sending alert for tx %s (%d burn(s))", records[0].TxHash, len(records))
+	d.notify(alert)
+	return len(records), nil
+}
+
+// formatStoredBurn renders a burn alert from a stored record. Details that
+// aren't persisted (holders, links, DEX) are left out.
+func (d *LPBurnDetector) formatStoredBurn(rec BurnRecord) string {
+	msg := d.messages
+
+	honeypotStatus := msg.Unknown + " 🟨"
+	if rec.IsHoneypot == "0" {
+		honeypotStatus = msg.False + " 🟩"
+	} else if rec.IsHoneypot == "1" {
+		honeypotStatus = msg.True + " 🟥"
+	}
+
+	formatTax := func(tax string) string {
+		if !taxUnknown(tax) {
+			if value, err := strconv.ParseFloat(tax, 64); err == nil {
+				return fmt.Sprintf("%.1f%%", value*100)
+			}
+		}
+		return msg.Unknown + " 🟨"
+	}
+
+	amountLine := fmt.Sprintf("<b>⎿ %s:</b> %.1f(%.2f%%)", msg.Burned, rec.BurnedLP, rec.BurnPercent)
+	if rec.BurnMethod != "" {
+		amountLine += fmt.Sprintf("\n        <b>⎿ %s:</b> %s", msg.BurnMethod, rec.BurnMethod)
+	}
+
+	cloggedLine := ""
+	if rec.Clogged > 0 || rec.CloggedPercent > 0 {
+		cloggedLine = fmt.Sprintf("\n        <b>⎿ %s:</b> %s (%.1f%%)", msg.Clogged, formatNumber(int64(rec.Clogged)), rec.CloggedPercent)
+	}
+
+	holderCount := msg.Unknown
+	if rec.HolderCount > 0 {
+		holderCount = formatNumber(rec.HolderCount)
+	}
+
+	header := ""
+	if rec.Risk != "" {
+		header = d.formatRisk(riskAssessment{Level: rec.Risk, Score: rec.RiskScore, Signals: rec.RiskSignals})
+	}
+
+	return fmt.Sprintf(`%s%s (%s, %s)
+<a href="%s/address/%s">%s</a><b>(%s)</b>
+<code>%s</code>
+
+💰<b>%s:</b> $%s
+        <b>⎿ %s:</b> <a href="%s/tx/%s">%s</a>
+        %s
+
+🔵 %s : %s
+        <b>⎿ %s:</b> %s
+        <b>⎿ %s:</b> %s%s
+
+👤 %s: %s%s`,
+		header, msg.NewBurn, msg.Resent, rec.DetectedAt.UTC().Format("2006-01-02 15:04 UTC"),
+		d.config.ExplorerURL, rec.TokenAddress, html.EscapeString(rec.TokenName), html.EscapeString(rec.TokenSymbol), rec.TokenAddress,
+		msg.Mcap, formatNumber(rec.Mcap), msg.Hash, d.config.ExplorerURL, rec.TxHash, msg.ClickHere, amountLine,
+		msg.Honeypot, honeypotStatus, msg.BuyTax, formatTax(rec.BuyTax), msg.SellTax, formatTax(rec.SellTax), cloggedLine,
+		msg.HolderCount, holderCount, d.alertFooter())
+}
+
+// handleResend serves POST /burns/{id}/resend, re-sending the stored alert
+// for an event ID or tx hash.
+func (d *LPBurnDetector) handleResend(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	sent, err := d.resendBurn(id)
+	if err != nil {
+		log.Printf("Failed to re-send burn %s: %v", id, err)
+		http.Error(w, "burn not found", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"id":     id,
+		"resent": sent,
+	})
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// resyncReorgDepth is how far the head may step back, as in an ordinary
+// reorg, before it's taken as the node replaying blocks.
+const resyncReorgDepth = 3
+
+// resyncGuard watches new heads for signs that the node is resyncing: heads
+// older than ResyncStaleAge or the head jumping back. While it is, alerts
+// are suppressed until heads have looked normal for ResyncSettle.
+type resyncGuard struct {
+	mu            sync.Mutex
+	lastHead      uint64
+	suppressUntil time.Time
+	suppressing   bool
+}
+
+// observeHead feeds a new head into the guard.
+func (d *LPBurnDetector) observeHead(header *types.Header) {
+	if d.config.ResyncStaleAge <= 0 {
+		return
+	}
+
+	now := d.clock.Now()
+	number := header.Number.Uint64()
+	age := now.Sub(time.Unix(int64(header.Time), 0))
+
+	g := &d.resync
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	reason := ""
+	if age > d.config.ResyncStaleAge {
+		reason = fmt.Sprintf("head block %s old", age.Round(time.Second))
+	} else if number+resyncReorgDepth < g.lastHead {
+		reason = fmt.Sprintf("head went back from block %d to %d", g.lastHead, number)
+	}
+	g.lastHead = number
+
+	if reason == "" {
+		return
+	}
+	if !g.suppressing {
+		log.Printf("🔇 Node appears to be resyncing (%s), suppressing alerts", reason)
+	}
+	g.suppressing = true
+	g.suppressUntil = now.Add(d.config.ResyncSettle)
+}
+
+// resyncing reports whether alerts are currently suppressed.
+func (d *LPBurnDetector) resyncing() bool {
+	g := &d.resync
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.suppressing {
+		return false
+	}
+	if d.clock.Now().Before(g.suppressUntil) {
+		return true
+	}
+	g.suppressing = false
+	log.Printf("🔊 Node head stable for %s, alerting again", d.config.ResyncSettle)
+	return false
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const maxRetryDelay = 30 * time.Second
+
+// Provider failures are classified so callers can branch on errors.Is:
+// ErrRateLimited for 429s, ErrNotFound when the provider has nothing for
+// the address, and ErrUpstream for 5xx, network and malformed responses.
+var (
+	ErrRateLimited = errors.New("rate limited")
+	ErrNotFound    = errors.New("not found")
+	ErrUpstream    = errors.New("upstream failure")
+)
+
+// httpStatusError is returned for non-200 responses so callers can tell
+// client errors from transient upstream failures.
+type httpStatusError struct {
+	StatusCode int
+	RetryAfter time.Duration
+	Body       string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// Is maps the status code onto the provider error classes.
+func (e *httpStatusError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUpstream:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+func newHTTPStatusError(resp *http.Response, body []byte) *httpStatusError {
+	err := &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	if secs, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && secs > 0 {
+		err.RetryAfter = time.Duration(secs) * time.Second
+	}
+	return err
+}
+
+// retryable reports whether err is worth retrying: network errors and
+// timeouts, 429 and 5xx. Other 4xx responses and decode errors are not.
+func retryable(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// withRetry runs op up to maxAttempts times, backing off exponentially with
+// jitter between retryable failures, timed by clock. A Retry-After from the
+// server takes precedence over the computed delay.
+func withRetry(ctx context.Context, clock Clock, name string, maxAttempts int, baseDelay time.Duration, op func() error) error {
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err = op(); err == nil || !retryable(err) || attempt == maxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		delay := baseDelay << (attempt - 1)
+		delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+		var statusErr *httpStatusError
+		if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+			delay = statusErr.RetryAfter
+		}
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+		if !spendRetry(ctx, delay) {
+			logf(ctx, "%s failed (attempt %d/%d), retry budget exhausted: %v", name, attempt, maxAttempts, err)
+			return err
+		}
+
+		logf(ctx, "%s failed (attempt %d/%d), retrying in %s: %v", name, attempt, maxAttempts, delay.Round(time.Millisecond), err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(delay):
+		}
+	}
+	return err
+}
+
+// httpDo makes one request and returns the body of a 200 response. Other
+// statuses come back as *httpStatusError; transport failures wrap
+// ErrUpstream. ctx bounds the whole exchange.
+func httpDo(ctx context.Context, client *http.Client, method, reqURL string, headers map[string]string, body []byte) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUpstream, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUpstream, err)
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, newHTTPStatusError(resp, data)
+	}
+	return data, nil
+}
+
+// callProvider runs op, usually an httpDo, through the provider's circuit
+// breaker with withRetry's backoff. Every outbound HTTP call goes through
+// it: httpGet for the data providers, the notifiers and the quote price
+// cache.
+func callProvider(ctx context.Context, breaker *CircuitBreaker, clock Clock, name string, maxAttempts int, baseDelay time.Duration, op func() error) error {
+	if breaker == nil {
+		return fmt.Errorf("%s: no circuit breaker configured", name)
+	}
+	return breaker.call(ctx, func() error {
+		return withRetry(ctx, clock, name, maxAttempts, baseDelay, op)
+	})
+}
+
+// httpGet calls the provider name under ctx and returns the body of the
+// first 200 response. Transient failures are retried with backoff,
+// honouring Retry-After, and calls go through the provider's circuit
+// breaker, failing fast while it is open.
+func (d *LPBurnDetector) httpGet(ctx context.Context, name, reqURL string, headers map[string]string) ([]byte, error) {
+	var body []byte
+	err := callProvider(ctx, d.breakers[name], d.clock, name, d.config.HTTPMaxAttempts, d.config.HTTPRetryBaseDelay, func() error {
+		var err error
+		body, err = httpDo(ctx, d.httpClient, "GET", reqURL, headers, nil)
+		return err
+	})
+	if err != nil && ctx.Err() == nil {
+		d.stats.apiErrors.Add(1)
+	}
+	return body, err
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type retryBudgetKey struct{}
+
+// retryBudget caps the retries one burn may spend across all its
+// sub-operations (tx lookups, API calls), by count and by total backoff, so
+// a problematic token can't hold up the pipeline. A zero limit is unlimited.
+type retryBudget struct {
+	mu      sync.Mutex
+	retries int
+	wait    time.Duration
+	limited bool
+	timed   bool
+}
+
+func withRetryBudget(ctx context.Context, retries int, wait time.Duration) context.Context {
+	return context.WithValue(ctx, retryBudgetKey{}, &retryBudget{
+		retries: retries,
+		wait:    wait,
+		limited: retries > 0,
+		timed:   wait > 0,
+	})
+}
+
+// spendRetry reports whether ctx's budget allows one more retry after
+// waiting delay, and charges it if so. Without a budget it always allows.
+func spendRetry(ctx context.Context, delay time.Duration) bool {
+	b, ok := ctx.Value(retryBudgetKey{}).(*retryBudget)
+	if !ok {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if (b.limited && b.retries <= 0) || (b.timed && delay > b.wait) {
+		return false
+	}
+	b.retries--
+	b.wait -= delay
+	return true
+}
+
+// newEventContext starts the context one burn event is processed under: a
+// fresh event ID, the per-burn retry budget and ProcessTimeout.
+func (d *LPBurnDetector) newEventContext() (context.Context, context.CancelFunc) {
+	ctx := withEventID(context.Background(), newEventID())
+	ctx = withRetryBudget(ctx, d.config.RetryBudget, d.config.RetryBudgetTime)
+	return context.WithTimeout(ctx, d.config.ProcessTimeout)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithRetryBacksOffOnClock(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+	base := time.Second
+
+	attempts := 0
+	err := withRetry(context.Background(), clock, "test", 3, base, func() error {
+		attempts++
+		if attempts < 3 {
+			return &httpStatusError{StatusCode: 503}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+
+	// Two backoffs of base and 2*base, each with up to 50% jitter
+	waited := clock.Now().Sub(start)
+	if waited < 3*base || waited > 3*base+3*base/2 {
+		t.Errorf("waited %s, want between %s and %s", waited, 3*base, 3*base+3*base/2)
+	}
+}
+
+func TestWithRetryHonoursRetryAfter(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+
+	attempts := 0
+	withRetry(context.Background(), clock, "test", 2, time.Second, func() error {
+		attempts++
+		if attempts == 1 {
+			return &httpStatusError{StatusCode: 429, RetryAfter: 7 * time.Second}
+		}
+		return nil
+	})
+	if waited := clock.Now().Sub(start); waited != 7*time.Second {
+		t.Errorf("waited %s, want 7s", waited)
+	}
+}
+
+func TestWithRetryStopsOnClientError(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+
+	attempts := 0
+	err := withRetry(context.Background(), clock, "test", 3, time.Second, func() error {
+		attempts++
+		return &httpStatusError{StatusCode: 400}
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("attempts = %d, err = %v; want one failed attempt", attempts, err)
+	}
+	if !clock.Now().Equal(start) {
+		t.Errorf("clock moved by %s, want no wait", clock.Now().Sub(start))
+	}
+}
+
+func TestHTTPGetUnknownProvider(t *testing.T) {
+	d, _ := newTestDetector(t, nil)
+
+	// A provider without a breaker fails the call instead of panicking
+	if _, err := d.httpGet(context.Background(), "NoSuchProvider", "https://example.com", nil); err == nil {
+		t.Error("httpGet with an unknown provider succeeded")
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Risk verdicts for a burn, from the summed weights of the signals below.
+const (
+	RiskSafe    = "SAFE"
+	RiskCaution = "CAUTION"
+	RiskDanger  = "DANGER"
+)
+
+// Risk signals, keyed as in RISK_WEIGHTS. Each adds its weight to the score
+// when it fires; unknown data never fires a signal.
+//
+//	honeypot       GoPlus flags the token as a honeypot
+//	tax            buy or sell tax above MAX_SAFE_TAX (once, not per side)
+//	owner          ownership isn't renounced (owner not zero or dead)
+//	source         the contract source isn't verified
+//	concentration  the top 10 holders, excluding the pool and burn
+//	               addresses, hold over RISK_CONCENTRATION_PERCENT
+//	burn           under RISK_MIN_BURN_PERCENT of the LP was burned
+//
+// A score of at least RISK_DANGER_SCORE is DANGER, at least
+// RISK_CAUTION_SCORE is CAUTION, and anything lower is SAFE.
+const (
+	riskHoneypot      = "honeypot"
+	riskTax           = "tax"
+	riskOwner         = "owner"
+	riskSource        = "source"
+	riskConcentration = "concentration"
+	riskBurn          = "burn"
+)
+
+var riskSignals = []string{riskHoneypot, riskTax, riskOwner, riskSource, riskConcentration, riskBurn}
+
+// riskConcentrationHolders is how many of the largest holders are summed
+// for the concentration signal.
+const riskConcentrationHolders = 10
+
+// riskAssessment is the computed verdict for one burn.
+type riskAssessment struct {
+	Level   string
+	Score   int
+	Signals []string // the signals that fired, in riskSignals order
+}
+
+// assessRisk combines a burn's risk signals into one verdict. burnShare is
+// the percentage of the LP supply burned.
+func (d *LPBurnDetector) assessRisk(details *TokenDetails, lpAddress common.Address, burnShare float64) riskAssessment {
+	fired := map[string]bool{
+		riskHoneypot: details.IsHoneypot == "1",
+		riskSource:   details.IsOpenSource == "0",
+		riskBurn:     burnShare > 0 && burnShare < d.config.RiskMinBurnPercent,
+	}
+
+	for _, tax := range []string{details.BuyTax, details.SellTax} {
+		if value, err := strconv.ParseFloat(tax, 64); err == nil && !taxUnknown(tax) && d.isHighTax(value) {
+			fired[riskTax] = true
+		}
+	}
+
+	if details.OwnerAddress != "" && common.IsHexAddress(details.OwnerAddress) {
+		owner := common.HexToAddress(details.OwnerAddress)
+		fired[riskOwner] = owner != (common.Address{}) && !d.isDeadAddress(owner)
+	}
+
+	fired[riskConcentration] = d.holderConcentration(details.Holders, lpAddress) > d.config.RiskConcentrationPercent
+
+	var risk riskAssessment
+	for _, signal := range riskSignals {
+		if fired[signal] {
+			risk.Score += d.config.RiskWeights[signal]
+			risk.Signals = append(risk.Signals, signal)
+		}
+	}
+
+	switch {
+	case risk.Score >= d.config.RiskDangerScore:
+		risk.Level = RiskDanger
+	case risk.Score >= d.config.RiskCautionScore:
+		risk.Level = RiskCaution
+	default:
+		risk.Level = RiskSafe
+	}
+	return risk
+}
+
+// holderConcentration is the percentage of supply held by the largest
+// holders other than the pool and the burn addresses.
+func (d *LPBurnDetector) holderConcentration(holders []Holder, lpAddress common.Address) float64 {
+	var percents []float64
+	for _, holder := range holders {
+		addr := common.HexToAddress(holder.Address)
+		if addr == lpAddress || addr == (common.Address{}) || d.isDeadAddress(addr) {
+			continue
+		}
+		if percent, err := strconv.ParseFloat(holder.Percent, 64); err == nil {
+			percents = append(percents, percent*100)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(percents)))
+
+	var total float64
+	for i, percent := range percents {
+		if i == riskConcentrationHolders {
+			break
+		}
+		total += percent
+	}
+	return total
+}
+
+// formatRisk renders the verdict as the alert's first line.
+func (d *LPBurnDetector) formatRisk(risk riskAssessment) string {
+	msg := d.messages
+	label := msg.RiskSafe
+	icon := "🟢"
+	switch risk.Level {
+	case RiskCaution:
+		label, icon = msg.RiskCaution, "🟡"
+	case RiskDanger:
+		label, icon = msg.RiskDanger, "🔴"
+	}
+
+	line := fmt.Sprintf("%s <b>%s: %s</b> (%d)", icon, msg.Risk, label, risk.Score)
+	if len(risk.Signals) > 0 {
+		line += " · " + strings.Join(risk.Signals, ", ")
+	}
+	return line + "\n"
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	defaultBurnsLimit = 20
+	maxBurnsLimit     = 100
+
+	defaultLeaderboardRange = 24 * time.Hour
+	maxLeaderboardRange     = 31 * 24 * time.Hour
+)
+
+func (d *LPBurnDetector) serveHTTP(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/burns", d.handleBurns)
+	mux.HandleFunc("POST /burns/{id}/resend", d.handleResend)
+	mux.HandleFunc("DELETE /first-burn/{token}", d.handleResetFirstBurn)
+	mux.HandleFunc("/metrics", d.handleMetrics)
+	mux.HandleFunc("GET /leaderboard", d.handleLeaderboard)
+	mux.HandleFunc("GET /healthz", d.handleHealthz)
+
+	log.Printf("🌐 HTTP server listening on %s", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Printf("❌ HTTP server stopped: %v", err)
+	}
+}
+
+// handleHealthz serves GET /healthz: the node connection state and the
+// build that is running.
+func (d *LPBurnDetector) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"status":    "ok",
+		"connected": d.stats.connected.Load(),
+		"mode":      d.subscriptionMode(),
+		"build":     currentBuildInfo(),
+	})
+}
+
+// handleBurns serves GET /burns?limit=N&offset=M, newest burns first.
+func (d *LPBurnDetector) handleBurns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit, err := queryInt(r, "limit", defaultBurnsLimit)
+	if err != nil || limit < 1 {
+		http.Error(w, "invalid limit", http.StatusBadRequest)
+		return
+	}
+	if limit > maxBurnsLimit {
+		limit = maxBurnsLimit
+	}
+
+	offset, err := queryInt(r, "offset", 0)
+	if err != nil || offset < 0 {
+		http.Error(w, "invalid offset", http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"total":  d.store.Len(),
+		"offset": offset,
+		"limit":  limit,
+		"burns":  d.store.Recent(offset, limit),
+	})
+}
+
+// handleResetFirstBurn serves DELETE /first-burn/{token}, forgetting the
+// token's recorded burns so its next burn alerts in first-burn-only mode.
+func (d *LPBurnDetector) handleResetFirstBurn(w http.ResponseWriter, r *http.Request) {
+	token := r.PathValue("token")
+	if !common.IsHexAddress(token) {
+		http.Error(w, "invalid token address", http.StatusBadRequest)
+		return
+	}
+
+	removed, err := d.store.ResetToken(token)
+	if err != nil {
+		log.Printf("Failed to reset first burn for %s: %v", token, err)
+		http.Error(w, "failed to reset token", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("🔄 Reset first-burn state for %s (%d record(s) removed)", token, removed)
+	writeJSON(w, map[string]interface{}{
+		"token":   token,
+		"removed": removed,
+	})
+}
+
+// handleLeaderboard serves GET /leaderboard?by=usd|percent&range=24h&limit=N,
+// the biggest burns detected within range, ranked from 1.
+func (d *LPBurnDetector) handleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	limit, err := queryInt(r, "limit", defaultBurnsLimit)
+	if err != nil || limit < 1 {
+		http.Error(w, "invalid limit", http.StatusBadRequest)
+		return
+	}
+	if limit > maxBurnsLimit {
+		limit = maxBurnsLimit
+	}
+
+	window := defaultLeaderboardRange
+	if val := r.URL.Query().Get("range"); val != "" {
+		if window, err = time.ParseDuration(val); err != nil || window <= 0 {
+			http.Error(w, "invalid range", http.StatusBadRequest)
+			return
+		}
+	}
+	if window > maxLeaderboardRange {
+		window = maxLeaderboardRange
+	}
+
+	by := r.URL.Query().Get("by")
+	var value func(rec BurnRecord) float64
+	switch by {
+	case "", "usd":
+		by = "usd"
+		value = func(rec BurnRecord) float64 { return rec.BurnedUSD }
+	case "percent":
+		value = func(rec BurnRecord) float64 { return rec.BurnPercent }
+	default:
+		http.Error(w, "invalid by: want usd or percent", http.StatusBadRequest)
+		return
+	}
+
+	// Equal values fall back to the earlier burn, then the tx hash
+	top := d.store.Top(d.clock.Now().Add(-window), limit, func(a, b BurnRecord) bool {
+		if va, vb := value(a), value(b); va != vb {
+			return va > vb
+		}
+		if !a.DetectedAt.Equal(b.DetectedAt) {
+			return a.DetectedAt.Before(b.DetectedAt)
+		}
+		return a.TxHash < b.TxHash
+	})
+
+	type entry struct {
+		Rank  int     `json:"rank"`
+		Value float64 `json:"value"`
+		BurnRecord
+	}
+	entries := make([]entry, len(top))
+	for i, rec := range top {
+		entries[i] = entry{Rank: i + 1, Value: value(rec), BurnRecord: rec}
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"by":      by,
+		"range":   window.String(),
+		"limit":   limit,
+		"entries": entries,
+	})
+}
+
+func queryInt(r *http.Request, key string, def int) (int, error) {
+	val := r.URL.Query().Get(key)
+	if val == "" {
+		return def, nil
+	}
+	return strconv.Atoi(val)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write JSON response: %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// skipError marks an expected reason for a dead-address transfer not being
+// an alertable LP burn (most transfers on mainnet are plain token burns).
+// These are logged sampled rather than individually.
+type skipError struct {
+	reason string
+}
+
+func (e *skipError) Error() string {
+	return e.reason
+}
+
+func skipf(format string, args ...interface{}) error {
+	return &skipError{reason: fmt.Sprintf(format, args...)}
+}
+
+func isSkip(err error) bool {
+	var skip *skipError
+	return errors.As(err, &skip)
+}
+
+// ErrReverted marks a contract read that reverted or returned nothing, as
+// opposed to the node failing to answer: retrying won't help.
+var ErrReverted = errors.New("call reverted")
+
+// revertError wraps err with ErrReverted when the node reported a revert.
+func revertError(err error) error {
+	if strings.Contains(strings.ToLower(err.Error()), "revert") {
+		return fmt.Errorf("%w: %v", ErrReverted, err)
+	}
+	return err
+}
+
+// readError wraps a failed contract read. Running out of the CallGasLimit
+// budget means the contract is pathological (or hostile) rather than the
+// node being unwell, so it is a skip.
+func readError(what string, err error) error {
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "out of gas") || strings.Contains(msg, "gas required exceeds") {
+		return skipf("%s: %v", what, err)
+	}
+	return fmt.Errorf("%s: %v", what, err)
+}
+
+// skipLogger logs one in every N skips and periodically summarizes how many
+// were seen, keeping the log readable on busy chains.
+type skipLogger struct {
+	mu      sync.Mutex
+	every   int
+	seen    uint64
+	pending int
+	clock   Clock
+}
+
+func newSkipLogger(every int, clock Clock) *skipLogger {
+	return &skipLogger{every: every, clock: clock}
+}
+
+func (s *skipLogger) log(err error) {
+	s.mu.Lock()
+	s.seen++
+	s.pending++
+	sample := s.every > 0 && (s.seen-1)%uint64(s.every) == 0
+	s.mu.Unlock()
+
+	if sample {
+		log.Printf("❌ Not an LP burn (sampled 1/%d): %v", s.every, err)
+	}
+}
+
+// run logs a summary of the skips seen in each interval.
+func (s *skipLogger) run(interval time.Duration) {
+	for {
+		<-s.clock.After(interval)
+
+		s.mu.Lock()
+		count := s.pending
+		s.pending = 0
+		s.mu.Unlock()
+
+		if count > 0 {
+			log.Printf("📊 Skipped %d non-LP-burn transfer(s) in the last %s", count, interval)
+		}
+	}
+}
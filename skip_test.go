@@ -0,0 +1,29 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRevertError(t *testing.T) {
+	tests := []struct {
+		err      string
+		reverted bool
+	}{
+		{"execution reverted", true},
+		{"execution reverted: ERC20: balance query for the zero address", true},
+		{"VM Exception while processing transaction: Revert", true},
+		{"connection refused", false},
+		{"context deadline exceeded", false},
+	}
+	for _, tt := range tests {
+		in := errors.New(tt.err)
+		err := revertError(in)
+		if got := errors.Is(err, ErrReverted); got != tt.reverted {
+			t.Errorf("revertError(%q) reverted = %v, want %v", tt.err, got, tt.reverted)
+		}
+		if !tt.reverted && err != in {
+			t.Errorf("revertError(%q) = %v, want the error unchanged", tt.err, err)
+		}
+	}
+}
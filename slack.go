@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"html"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// slackMinInterval paces posts to Slack's one-message-per-second webhook
+// limit.
+const slackMinInterval = time.Second
+
+// SlackNotifier posts alerts to a Slack incoming webhook, converted from
+// Telegram HTML to mrkdwn. Like Telegram, alerts are queued and sent by run.
+type SlackNotifier struct {
+	httpClient  *http.Client
+	webhookURL  string
+	channel     string
+	timeout     time.Duration
+	maxAttempts int
+	baseDelay   time.Duration
+	breaker     *CircuitBreaker
+	queue       *alertQueue
+	clock       Clock
+}
+
+func NewSlackNotifier(httpClient *http.Client, webhookURL, channel string, timeout time.Duration, maxAttempts int, baseDelay time.Duration, breaker *CircuitBreaker, queueSize int, clock Clock) *SlackNotifier {
+	return &SlackNotifier{
+		httpClient:  httpClient,
+		webhookURL:  webhookURL,
+		channel:     channel,
+		timeout:     timeout,
+		maxAttempts: maxAttempts,
+		baseDelay:   baseDelay,
+		breaker:     breaker,
+		queue:       newAlertQueue(queueSize),
+		clock:       clock,
+	}
+}
+
+func (s *SlackNotifier) Name() string {
+	return "slack"
+}
+
+func (s *SlackNotifier) Notify(alert Alert) error {
+	if dropped := s.queue.push(alert); dropped != nil {
+		log.Printf("⚠️ Slack queue full, dropped alert with mcap $%s", formatNumber(dropped.Mcap))
+	}
+	return nil
+}
+
+// run drains the alert queue, posting at most one message per
+// slackMinInterval, until ctx is cancelled.
+func (s *SlackNotifier) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.queue.ready:
+		}
+
+		for {
+			alert, ok := s.queue.pop()
+			if !ok {
+				break
+			}
+
+			postCtx, cancel := context.WithTimeout(ctx, s.timeout)
+			err := callProvider(postCtx, s.breaker, s.clock, "Slack", s.maxAttempts, s.baseDelay, func() error {
+				return s.post(postCtx, htmlToMrkdwn(alert.Text))
+			})
+			cancel()
+			if err != nil {
+				log.Printf("❌ Failed to send Slack message: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.clock.After(slackMinInterval):
+			}
+		}
+	}
+}
+
+func (s *SlackNotifier) post(ctx context.Context, text string) error {
+	payload := map[string]interface{}{
+		"text":         text,
+		"unfurl_links": false,
+	}
+	if s.channel != "" {
+		payload["channel"] = s.channel
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = httpDo(ctx, s.httpClient, "POST", s.webhookURL, map[string]string{"Content-Type": "application/json"}, data)
+	return err
+}
+
+var (
+	htmlTagTokenPattern = regexp.MustCompile(`<(/?)([a-zA-Z]+)([^>]*)>`)
+	hrefPattern         = regexp.MustCompile(`href="([^"]*)"`)
+)
+
+var mrkdwnMarks = map[string]string{
+	"b":      "*",
+	"strong": "*",
+	"i":      "_",
+	"em":     "_",
+	"s":      "~",
+	"code":   "`",
+}
+
+// htmlToMrkdwn converts the Telegram HTML of an alert to Slack mrkdwn:
+// links become <url|text>, bold, italic, strike and code keep their
+// markers, and any other tag is dropped.
+func htmlToMrkdwn(s string) string {
+	var out, linkText strings.Builder
+	var href string
+	inLink := false
+
+	write := func(text string) {
+		if inLink {
+			linkText.WriteString(text)
+		} else {
+			out.WriteString(text)
+		}
+	}
+
+	last := 0
+	for _, m := range htmlTagTokenPattern.FindAllStringSubmatchIndex(s, -1) {
+		write(slackEscape(html.UnescapeString(s[last:m[0]])))
+		last = m[1]
+
+		closing := s[m[2]:m[3]] == "/"
+		tag := strings.ToLower(s[m[4]:m[5]])
+		switch {
+		case tag == "a" && !closing:
+			href = ""
+			if h := hrefPattern.FindStringSubmatch(s[m[6]:m[7]]); h != nil {
+				href = html.UnescapeString(h[1])
+			}
+			inLink = true
+			linkText.Reset()
+		case tag == "a" && closing && inLink:
+			inLink = false
+			if href == "" {
+				out.WriteString(linkText.String())
+			} else {
+				out.WriteString("<" + href + "|" + linkText.String() + ">")
+			}
+		case mrkdwnMarks[tag] != "":
+			write(mrkdwnMarks[tag])
+		}
+	}
+	write(slackEscape(html.UnescapeString(s[last:])))
+	if inLink {
+		out.WriteString(linkText.String())
+	}
+	return out.String()
+}
+
+// slackEscape escapes the characters Slack treats as control sequences.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
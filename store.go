@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BurnRecord is the persisted form of a detected burn, carrying every figure
+// that went into its alert.
+type BurnRecord struct {
+	EventID        string    `json:"event_id,omitempty"`
+	TxHash         string    `json:"tx_hash"`
+	BlockNumber    uint64    `json:"block_number,omitempty"`
+	LPAddress      string    `json:"lp_address"`
+	TokenAddress   string    `json:"token_address"`
+	TokenName      string    `json:"token_name"`
+	TokenSymbol    string    `json:"token_symbol"`
+	BurnedLP       float64   `json:"burned_lp"`
+	BurnPercent    float64   `json:"burn_percent"`
+	BurnMethod     string    `json:"burn_method,omitempty"`
+	BurnedUSD      float64   `json:"burned_usd,omitempty"`
+	PriceUSD       string    `json:"price_usd"`
+	PriceSource    string    `json:"price_source,omitempty"`
+	Mcap           int64     `json:"mcap"`
+	IsHoneypot     string    `json:"is_honeypot"`
+	BuyTax         string    `json:"buy_tax"`
+	SellTax        string    `json:"sell_tax"`
+	Clogged        float64   `json:"clogged"`
+	CloggedPercent float64   `json:"clogged_percent"`
+	HolderCount    int64     `json:"holder_count"`
+	Risk           string    `json:"risk,omitempty"`
+	RiskScore      int       `json:"risk_score,omitempty"`
+	RiskSignals    []string  `json:"risk_signals,omitempty"`
+	DetectedAt     time.Time `json:"detected_at"`
+}
+
+// BurnStore keeps detected burns in memory and appends each one to a
+// JSON-lines file so the history survives restarts.
+type BurnStore struct {
+	mu      sync.RWMutex
+	path    string
+	records []BurnRecord
+	tokens  map[string]int // burns per lowercase token address
+}
+
+func OpenBurnStore(path string) (*BurnStore, error) {
+	store := &BurnStore{path: path, tokens: make(map[string]int)}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open burn store: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		var rec BurnRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			log.Printf("Skipping malformed burn record on line %d: %v", line, err)
+			continue
+		}
+		store.records = append(store.records, rec)
+		store.tokens[strings.ToLower(rec.TokenAddress)]++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read burn store: %v", err)
+	}
+
+	return store, nil
+}
+
+func (s *BurnStore) Add(rec BurnRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	s.records = append(s.records, rec)
+	s.tokens[strings.ToLower(rec.TokenAddress)]++
+	return nil
+}
+
+// HasToken reports whether any burn has been recorded for token.
+func (s *BurnStore) HasToken(token string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tokens[strings.ToLower(token)] > 0
+}
+
+// ResetToken deletes every recorded burn for token, rewriting the store
+// file, so the token alerts again in first-burn-only mode. It returns the
+// number of records removed.
+func (s *BurnStore) ResetToken(token string) (int, error) {
+	token = strings.ToLower(token)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tokens[token] == 0 {
+		return 0, nil
+	}
+
+	kept := make([]BurnRecord, 0, len(s.records))
+	for _, rec := range s.records {
+		if strings.ToLower(rec.TokenAddress) != token {
+			kept = append(kept, rec)
+		}
+	}
+
+	if err := s.rewrite(kept); err != nil {
+		return 0, err
+	}
+
+	removed := len(s.records) - len(kept)
+	s.records = kept
+	delete(s.tokens, token)
+	return removed, nil
+}
+
+// rewrite replaces the store file with records, via a temp file so a crash
+// mid-write can't truncate the history.
+func (s *BurnStore) rewrite(records []BurnRecord) error {
+	tmp := s.path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	for _, rec := range records {
+		data, err := json.Marshal(rec)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		writer.Write(append(data, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.path)
+}
+
+func (s *BurnStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.records)
+}
+
+// Top returns up to limit records detected at or after since, ranked by
+// less. Ties keep detection order, so the ranking is deterministic.
+func (s *BurnStore) Top(since time.Time, limit int, less func(a, b BurnRecord) bool) []BurnRecord {
+	s.mu.RLock()
+	matched := []BurnRecord{}
+	for _, rec := range s.records {
+		if !rec.DetectedAt.Before(since) {
+			matched = append(matched, rec)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return less(matched[i], matched[j])
+	})
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched
+}
+
+// Recent returns up to limit records, newest first, skipping the newest
+// offset records.
+func (s *BurnStore) Recent(offset, limit int) []BurnRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []BurnRecord{}
+	for i := len(s.records) - 1 - offset; i >= 0 && len(result) < limit; i-- {
+		result = append(result, s.records[i])
+	}
+	return result
+}
+
+// burnedLiquidityUSD values the burned LP as its share of the pool's
+// liquidity; it is 0 when the liquidity is unknown.
+func burnedLiquidityUSD(liquidityUSD float64, burned, lpSupply *big.Int) float64 {
+	if liquidityUSD <= 0 || lpSupply.Sign() == 0 {
+		return 0
+	}
+	share, _ := newFloat().Quo(floatFromInt(burned), floatFromInt(lpSupply)).Float64()
+	return liquidityUSD * share
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// supplyWatch remembers the last totalSupply() seen for each pair on the
+// SupplyWatchPairs list.
+type supplyWatch struct {
+	mu      sync.Mutex
+	last    map[common.Address]*big.Int
+	running atomic.Bool
+}
+
+// pollSupplies reads the supply of every watched pair at blockNumber and
+// alerts on drops of SupplyDropFraction or more since the previous block.
+// This catches LP burns that never emit a Transfer to the dead address,
+// such as a burn() that just decrements supply. A poll still running when
+// the next head arrives makes that head skipped rather than queued.
+func (d *LPBurnDetector) pollSupplies(blockNumber *big.Int) {
+	if !d.supplyWatch.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer d.supplyWatch.running.Store(false)
+
+	for pair := range d.supplyWatchPairs {
+		ctx, cancel := d.newEventContext()
+		if err := d.checkSupply(ctx, pair, blockNumber); err != nil {
+			logf(ctx, "Failed to check supply of %s: %v", pair.Hex(), err)
+		}
+		cancel()
+	}
+}
+
+func (d *LPBurnDetector) checkSupply(ctx context.Context, pair common.Address, blockNumber *big.Int) error {
+	supply, err := d.getTokenSupply(ctx, pair, blockNumber)
+	if err != nil {
+		return err
+	}
+
+	d.supplyWatch.mu.Lock()
+	prev := d.supplyWatch.last[pair]
+	d.supplyWatch.last[pair] = supply
+	d.supplyWatch.mu.Unlock()
+
+	if prev == nil || prev.Sign() == 0 || supply.Cmp(prev) >= 0 {
+		return nil
+	}
+
+	dropped := new(big.Int).Sub(prev, supply)
+	fraction, _ := newFloat().Quo(floatFromInt(dropped), floatFromInt(prev)).Float64()
+	if fraction < d.config.SupplyDropFraction {
+		return nil
+	}
+
+	logf(ctx, "📉 Supply of %s dropped %.2f%% at block %s", pair.Hex(), fraction*100, blockNumber.String())
+	if d.config.DryRun {
+		return nil
+	}
+
+	symbol, err := d.getTokenSymbol(ctx, pair)
+	if err != nil {
+		symbol = d.messages.Unknown
+	}
+	decimals, err := d.getTokenDecimals(ctx, pair, blockNumber)
+	if err != nil {
+		decimals = 18
+	}
+	divisor := floatFromInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	droppedFormatted, _ := newFloat().Quo(floatFromInt(dropped), divisor).Float64()
+
+	msg := d.messages
+	text := fmt.Sprintf(`%s
+<a href="%s/address/%s">%s</a>
+<code>%s</code>
+
+        <b>⎿ %s:</b> %.1f LP (%.2f%%)
+        <b>⎿ %s:</b> %s%s`,
+		msg.SupplyDrop, d.config.ExplorerURL, pair.Hex(), symbol, pair.Hex(),
+		msg.Burned, droppedFormatted, fraction*100,
+		msg.Block, blockNumber.String(), d.alertFooter())
+
+	d.notify(Alert{Text: d.withAlertID(ctx, text), Thread: strings.ToLower(pair.Hex())})
+	return nil
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const ROUTER_ABI = `[
+	{
+		"inputs": [
+			{"name": "amountIn", "type": "uint256"},
+			{"name": "path", "type": "address[]"}
+		],
+		"name": "getAmountsOut",
+		"outputs": [{"name": "amounts", "type": "uint256[]"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"name": "amountOutMin", "type": "uint256"},
+			{"name": "path", "type": "address[]"},
+			{"name": "to", "type": "address"},
+			{"name": "deadline", "type": "uint256"}
+		],
+		"name": "swapExactETHForTokensSupportingFeeOnTransferTokens",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"name": "amountIn", "type": "uint256"},
+			{"name": "amountOutMin", "type": "uint256"},
+			{"name": "path", "type": "address[]"},
+			{"name": "to", "type": "address"},
+			{"name": "deadline", "type": "uint256"}
+		],
+		"name": "swapExactTokensForTokensSupportingFeeOnTransferTokens",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+// taxSimAccount is the throwaway sender used in simulations. It is funded
+// through a state override, so it never needs to exist on chain.
+var taxSimAccount = common.HexToAddress("0x00000000000000000000000000000000007a5e51")
+
+type simCall struct {
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value string         `json:"value,omitempty"`
+	Input string         `json:"input"`
+}
+
+type simBlock struct {
+	StateOverrides map[common.Address]map[string]string `json:"stateOverrides"`
+	Calls          []simCall                            `json:"calls"`
+}
+
+type simCallResult struct {
+	ReturnData string `json:"returnData"`
+	Status     string `json:"status"`
+	Error      *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+type simBlockResult struct {
+	Calls []simCallResult `json:"calls"`
+}
+
+// simulateTaxes measures the effective buy and sell tax of token by
+// simulating a round trip through the router: buy with ETH, then sell
+// everything received back to WETH. Each tax is the shortfall between what
+// the router quoted and what actually arrived.
+//
+// The balances have to be read between the swaps, which a single eth_call
+// can't do, so this uses eth_simulateV1 (eth_call over several calls with
+// state overrides). The node must support it.
+func (d *LPBurnDetector) simulateTaxes(ctx context.Context, token common.Address) (buyTax, sellTax float64, err error) {
+	router := common.HexToAddress(d.config.RouterAddr)
+	weth := d.weth
+	buyAmount := d.config.TaxSimBuyWei
+	deadline := big.NewInt(d.clock.Now().Add(time.Hour).Unix())
+
+	encode := func(contractABI abi.ABI) func(string, ...interface{}) (string, error) {
+		return func(method string, args ...interface{}) (string, error) {
+			data, err := contractABI.Pack(method, args...)
+			if err != nil {
+				return "", fmt.Errorf("failed to pack %s: %v", method, err)
+			}
+			return "0x" + hex.EncodeToString(data), nil
+		}
+	}
+	packRouter := encode(d.routerABI)
+	packToken := encode(d.contractABI)
+
+	// Phase one: quote and execute the buy.
+	quoteBuy, err := packRouter("getAmountsOut", buyAmount, []common.Address{weth, token})
+	if err != nil {
+		return 0, 0, err
+	}
+	buy, err := packRouter("swapExactETHForTokensSupportingFeeOnTransferTokens", big.NewInt(0), []common.Address{weth, token}, taxSimAccount, deadline)
+	if err != nil {
+		return 0, 0, err
+	}
+	tokenBalance, err := packToken("balanceOf", taxSimAccount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	results, err := d.simulate(ctx, []simCall{
+		{From: taxSimAccount, To: router, Input: quoteBuy},
+		{From: taxSimAccount, To: router, Value: fmt.Sprintf("0x%x", buyAmount), Input: buy},
+		{From: taxSimAccount, To: token, Input: tokenBalance},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	quotedTokens, err := d.unpackLastAmount(results[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("buy quote failed: %v", err)
+	}
+	receivedTokens, err := d.unpackBalance(results[2])
+	if err != nil {
+		return 0, 0, fmt.Errorf("buy failed: %v", err)
+	}
+	if receivedTokens.Sign() == 0 {
+		return 0, 0, fmt.Errorf("buy returned no tokens")
+	}
+	buyTax = taxFraction(quotedTokens, receivedTokens)
+
+	// Phase two: repeat the buy, then quote and execute the sell of
+	// everything received.
+	approve, err := packToken("approve", router, receivedTokens)
+	if err != nil {
+		return 0, 0, err
+	}
+	quoteSell, err := packRouter("getAmountsOut", receivedTokens, []common.Address{token, weth})
+	if err != nil {
+		return 0, 0, err
+	}
+	sell, err := packRouter("swapExactTokensForTokensSupportingFeeOnTransferTokens", receivedTokens, big.NewInt(0), []common.Address{token, weth}, taxSimAccount, deadline)
+	if err != nil {
+		return 0, 0, err
+	}
+	wethBalance, err := packToken("balanceOf", taxSimAccount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	results, err = d.simulate(ctx, []simCall{
+		{From: taxSimAccount, To: router, Value: fmt.Sprintf("0x%x", buyAmount), Input: buy},
+		{From: taxSimAccount, To: token, Input: approve},
+		{From: taxSimAccount, To: router, Input: quoteSell},
+		{From: taxSimAccount, To: router, Input: sell},
+		{From: taxSimAccount, To: weth, Input: wethBalance},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if results[3].Error != nil {
+		// A reverting sell is the honeypot signature: treat it as a 100% tax.
+		return buyTax, 1, nil
+	}
+	quotedWeth, err := d.unpackLastAmount(results[2])
+	if err != nil {
+		return 0, 0, fmt.Errorf("sell quote failed: %v", err)
+	}
+	receivedWeth, err := d.unpackBalance(results[4])
+	if err != nil {
+		return 0, 0, fmt.Errorf("sell failed: %v", err)
+	}
+	sellTax = taxFraction(quotedWeth, receivedWeth)
+
+	return buyTax, sellTax, nil
+}
+
+func (d *LPBurnDetector) simulate(ctx context.Context, calls []simCall) ([]simCallResult, error) {
+	opts := map[string]interface{}{
+		"blockStateCalls": []simBlock{{
+			StateOverrides: map[common.Address]map[string]string{
+				taxSimAccount: {"balance": "0x56bc75e2d63100000"}, // 100 ETH
+			},
+			Calls: calls,
+		}},
+		"validation": false,
+	}
+
+	var blocks []simBlockResult
+	if err := d.client.Client().CallContext(ctx, &blocks, "eth_simulateV1", opts, "latest"); err != nil {
+		return nil, fmt.Errorf("eth_simulateV1 failed: %v", err)
+	}
+	if len(blocks) != 1 || len(blocks[0].Calls) != len(calls) {
+		return nil, fmt.Errorf("unexpected eth_simulateV1 result shape")
+	}
+	return blocks[0].Calls, nil
+}
+
+func (d *LPBurnDetector) unpackLastAmount(res simCallResult) (*big.Int, error) {
+	data, err := simReturnData(res)
+	if err != nil {
+		return nil, err
+	}
+	var amounts []*big.Int
+	if err := d.routerABI.UnpackIntoInterface(&amounts, "getAmountsOut", data); err != nil {
+		return nil, err
+	}
+	if len(amounts) == 0 {
+		return nil, fmt.Errorf("empty amounts")
+	}
+	return amounts[len(amounts)-1], nil
+}
+
+func (d *LPBurnDetector) unpackBalance(res simCallResult) (*big.Int, error) {
+	data, err := simReturnData(res)
+	if err != nil {
+		return nil, err
+	}
+	var balance *big.Int
+	if err := d.contractABI.UnpackIntoInterface(&balance, "balanceOf", data); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+func simReturnData(res simCallResult) ([]byte, error) {
+	if res.Error != nil {
+		return nil, fmt.Errorf("call reverted: %s", res.Error.Message)
+	}
+	return hex.DecodeString(strings.TrimPrefix(res.ReturnData, "0x"))
+}
+
+// taxFraction returns 1 - received/quoted, clamped to [0, 1].
+func taxFraction(quoted, received *big.Int) float64 {
+	if quoted.Sign() == 0 {
+		return 0
+	}
+	ratio, _ := newFloat().Quo(floatFromInt(received), floatFromInt(quoted)).Float64()
+	tax := 1 - ratio
+	if tax < 0 {
+		return 0
+	}
+	if tax > 1 {
+		return 1
+	}
+	return tax
+}
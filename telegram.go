@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+	"unicode/utf8"
+)
+
+// telegramMaxMessageLength and telegramMaxCaptionLength are the Bot API
+// limits on the visible text of a message and of a photo caption.
+const (
+	telegramMaxMessageLength = 4096
+	telegramMaxCaptionLength = 1024
+)
+
+// telegramLength approximates how Telegram measures text: the characters
+// left once the HTML markup is parsed out.
+func telegramLength(text string) int {
+	return utf8.RuneCountInString(stripHTML(text))
+}
+
+// TelegramNotifier delivers alerts to a Telegram chat. Alerts are queued and
+// sent by run at most once per minInterval to stay under Telegram's limits.
+type TelegramNotifier struct {
+	httpClient  *http.Client
+	botToken    string
+	chatID      string
+	minInterval time.Duration
+	timeout     time.Duration
+	maxAttempts int
+	baseDelay   time.Duration
+	breaker     *CircuitBreaker
+	queue       *alertQueue
+
+	// threads maps an alert's Thread to the first message sent for it, so
+	// later alerts for the same LP reply to it. Only run touches it.
+	threads   map[string]TelegramMessage
+	threadTTL time.Duration
+	threadAt  map[string]time.Time
+
+	clock Clock
+}
+
+func NewTelegramNotifier(httpClient *http.Client, botToken, chatID string, minInterval, timeout time.Duration, maxAttempts int, baseDelay time.Duration, breaker *CircuitBreaker, queueSize int, threadTTL time.Duration, clock Clock) *TelegramNotifier {
+	return &TelegramNotifier{
+		httpClient:  httpClient,
+		botToken:    botToken,
+		chatID:      chatID,
+		minInterval: minInterval,
+		timeout:     timeout,
+		maxAttempts: maxAttempts,
+		baseDelay:   baseDelay,
+		breaker:     breaker,
+		queue:       newAlertQueue(queueSize),
+		threads:     make(map[string]TelegramMessage),
+		threadTTL:   threadTTL,
+		threadAt:    make(map[string]time.Time),
+		clock:       clock,
+	}
+}
+
+func (t *TelegramNotifier) Name() string {
+	return "telegram"
+}
+
+func (t *TelegramNotifier) Notify(alert Alert) error {
+	if dropped := t.queue.push(alert); dropped != nil {
+		log.Printf("⚠️ Alert queue full, dropped alert with mcap $%s", formatNumber(dropped.Mcap))
+	}
+	return nil
+}
+
+// run drains the alert queue, sending at most one message per minInterval,
+// until ctx is cancelled.
+func (t *TelegramNotifier) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.queue.ready:
+		}
+
+		for {
+			alert, ok := t.queue.pop()
+			if !ok {
+				break
+			}
+
+			root, threaded := t.threadRoot(alert.Thread)
+			sendCtx, cancel := context.WithTimeout(ctx, t.timeout)
+			msg, err := t.deliver(sendCtx, alert, root.MessageID)
+			cancel()
+			if err != nil {
+				log.Printf("❌ Failed to send Telegram message: %v", err)
+			} else {
+				if !threaded {
+					t.startThread(alert.Thread, msg)
+				}
+				if alert.OnSent != nil {
+					alert.OnSent(msg, alert.Text)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.clock.After(t.minInterval):
+			}
+		}
+	}
+}
+
+// TelegramMessage identifies a sent message so it can later be edited,
+// pinned or replied to.
+type TelegramMessage struct {
+	MessageID int64
+	ChatID    int64
+
+	// Caption is set for a photo, whose text is its caption
+	Caption bool
+}
+
+type telegramSendResponse struct {
+	OK     bool `json:"ok"`
+	Result struct {
+		MessageID int64 `json:"message_id"`
+		Chat      struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"result"`
+	Description string `json:"description"`
+}
+
+// threadRoot returns the live first message of thread, if any. Threading is
+// off when threadTTL is 0.
+func (t *TelegramNotifier) threadRoot(thread string) (TelegramMessage, bool) {
+	if thread == "" || t.threadTTL <= 0 {
+		return TelegramMessage{}, false
+	}
+	at, ok := t.threadAt[thread]
+	if !ok || t.clock.Now().Sub(at) > t.threadTTL {
+		return TelegramMessage{}, false
+	}
+	return t.threads[thread], true
+}
+
+// startThread makes msg the root of thread, dropping expired threads.
+func (t *TelegramNotifier) startThread(thread string, msg TelegramMessage) {
+	if thread == "" || t.threadTTL <= 0 {
+		return
+	}
+	for key, at := range t.threadAt {
+		if t.clock.Now().Sub(at) > t.threadTTL {
+			delete(t.threads, key)
+			delete(t.threadAt, key)
+		}
+	}
+	t.threads[thread] = msg
+	t.threadAt[thread] = t.clock.Now()
+}
+
+// deliver sends alert to the alert chat. An alert with a photo goes out as
+// the photo captioned with its text; text too long for a caption follows
+// the photo as its own message, and a photo Telegram won't take is dropped.
+func (t *TelegramNotifier) deliver(ctx context.Context, alert Alert, replyTo int64) (TelegramMessage, error) {
+	if alert.PhotoURL == "" {
+		return t.sendTelegramMessage(ctx, alert.Text, replyTo)
+	}
+
+	caption := alert.Text
+	if telegramLength(caption) > telegramMaxCaptionLength {
+		caption = ""
+	}
+	msg, err := t.sendTelegramPhoto(ctx, alert.PhotoURL, caption, replyTo)
+	if err != nil {
+		log.Printf("⚠️ Failed to send alert photo, sending text only: %v", err)
+		return t.sendTelegramMessage(ctx, alert.Text, replyTo)
+	}
+	if caption == "" {
+		return t.sendTelegramMessage(ctx, alert.Text, replyTo)
+	}
+	return msg, nil
+}
+
+// sendTelegramPhoto sends the image at photoURL to the alert chat with an
+// optional caption.
+func (t *TelegramNotifier) sendTelegramPhoto(ctx context.Context, photoURL, caption string, replyTo int64) (TelegramMessage, error) {
+	data := url.Values{}
+	data.Set("chat_id", t.chatID)
+	data.Set("photo", photoURL)
+	if caption != "" {
+		data.Set("caption", caption)
+	}
+
+	msg, err := t.send(ctx, "sendPhoto", data, replyTo)
+	msg.Caption = true
+	return msg, err
+}
+
+// sendTelegramMessage sends message to the alert chat, as a reply to
+// replyTo unless it is 0.
+func (t *TelegramNotifier) sendTelegramMessage(ctx context.Context, message string, replyTo int64) (TelegramMessage, error) {
+	return t.sendToChat(ctx, t.chatID, message, replyTo)
+}
+
+// sendToChat sends a message to chatID directly, bypassing the alert queue.
+func (t *TelegramNotifier) sendToChat(ctx context.Context, chatID, message string, replyTo int64) (TelegramMessage, error) {
+	data := url.Values{}
+	data.Set("chat_id", chatID)
+	data.Set("text", message)
+	return t.send(ctx, "sendMessage", data, replyTo)
+}
+
+// send calls a Bot API method that posts a message and returns it.
+func (t *TelegramNotifier) send(ctx context.Context, method string, data url.Values, replyTo int64) (TelegramMessage, error) {
+	if replyTo != 0 {
+		data.Set("reply_to_message_id", fmt.Sprintf("%d", replyTo))
+		data.Set("allow_sending_without_reply", "true")
+	}
+
+	body, err := t.call(ctx, method, data)
+	if err != nil {
+		return TelegramMessage{}, err
+	}
+
+	var result telegramSendResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return TelegramMessage{}, fmt.Errorf("failed to parse telegram response: %v", err)
+	}
+	if !result.OK {
+		return TelegramMessage{}, fmt.Errorf("telegram API error: %s", result.Description)
+	}
+	return TelegramMessage{MessageID: result.Result.MessageID, ChatID: result.Result.Chat.ID}, nil
+}
+
+// editTelegramMessage replaces the text, or the caption of a photo, of a
+// message sent earlier.
+func (t *TelegramNotifier) editTelegramMessage(ctx context.Context, msg TelegramMessage, message string) error {
+	data := url.Values{}
+	data.Set("chat_id", fmt.Sprintf("%d", msg.ChatID))
+	data.Set("message_id", fmt.Sprintf("%d", msg.MessageID))
+
+	method := "editMessageText"
+	if msg.Caption {
+		method = "editMessageCaption"
+		data.Set("caption", message)
+	} else {
+		data.Set("text", message)
+	}
+
+	_, err := t.call(ctx, method, data)
+	return err
+}
+
+// call posts an HTML-formatted Bot API method and returns the response
+// body. 429s and transient failures are retried, honouring Retry-After.
+func (t *TelegramNotifier) call(ctx context.Context, method string, data url.Values) ([]byte, error) {
+	telegramURL := fmt.Sprintf("https://api.telegram.org/bot%s/%s", t.botToken, method)
+
+	data.Set("parse_mode", "HTML")
+	data.Set("disable_web_page_preview", "true")
+
+	var body []byte
+	err := callProvider(ctx, t.breaker, t.clock, "Telegram", t.maxAttempts, t.baseDelay, func() error {
+		var err error
+		body, err = httpDo(ctx, t.httpClient, "POST", telegramURL, map[string]string{"Content-Type": "application/x-www-form-urlencoded"}, []byte(data.Encode()))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("telegram API error: %w", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// decimalsCache remembers token decimals, which never change once a token
+// is deployed, so a flaky decimals() call doesn't force a guess for a token
+// seen before.
+type decimalsCache struct {
+	mu       sync.RWMutex
+	decimals map[common.Address]uint8
+}
+
+func newDecimalsCache() *decimalsCache {
+	return &decimalsCache{decimals: make(map[common.Address]uint8)}
+}
+
+func (c *decimalsCache) get(token common.Address) (uint8, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	decimals, ok := c.decimals[token]
+	return decimals, ok
+}
+
+func (c *decimalsCache) set(token common.Address, decimals uint8) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.decimals[token] = decimals
+}
+
+// symbolCache remembers token symbols; they're read for both sides of
+// every pool alerted on, and the quote side repeats constantly.
+type symbolCache struct {
+	mu      sync.RWMutex
+	symbols map[common.Address]string
+}
+
+func newSymbolCache() *symbolCache {
+	return &symbolCache{symbols: make(map[common.Address]string)}
+}
+
+func (c *symbolCache) get(token common.Address) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	symbol, ok := c.symbols[token]
+	return symbol, ok
+}
+
+func (c *symbolCache) set(token common.Address, symbol string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.symbols[token] = symbol
+}
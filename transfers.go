@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// burnTransfer is a transfer of LP tokens into the dead address.
+type burnTransfer struct {
+	LP    common.Address
+	Value *big.Int
+	Kind  lpEventKind
+}
+
+// transfersFromLogs decodes dead-address ERC20 Transfer events. Logs that
+// aren't ERC20 transfers (e.g. ERC721 transfers, which index the token id as
+// a fourth topic) are skipped, as are duplicates of the same log.
+func (d *LPBurnDetector) transfersFromLogs(logs []types.Log) []burnTransfer {
+	seen := make(map[uint]bool)
+	var transfers []burnTransfer
+	for _, vLog := range logs {
+		if len(vLog.Topics) != 3 || vLog.Topics[0] != transferEventTopic || len(vLog.Data) != 32 {
+			continue
+		}
+		if !d.isDeadAddress(topicAddress(vLog.Topics[2])) {
+			continue
+		}
+		if seen[vLog.Index] {
+			continue
+		}
+		seen[vLog.Index] = true
+
+		transfers = append(transfers, burnTransfer{
+			LP:    vLog.Address,
+			Value: new(big.Int).SetBytes(vLog.Data),
+		})
+	}
+	return transfers
+}
+
+// isZeroValueTransfer reports whether vLog is an ERC20 Transfer of nothing.
+func isZeroValueTransfer(vLog types.Log) bool {
+	if len(vLog.Topics) != 3 || vLog.Topics[0] != transferEventTopic || len(vLog.Data) != 32 {
+		return false
+	}
+	return new(big.Int).SetBytes(vLog.Data).Sign() == 0
+}
+
+// transferFromCallData decodes a burn from a tx that directly calls
+// transfer(dead, value) on the LP token.
+func (d *LPBurnDetector) transferFromCallData(tx *types.Transaction) (burnTransfer, error) {
+	// Check if it's a transfer function call (a9059cbb)
+	if len(tx.Data()) < 4 {
+		return burnTransfer{}, skipf("transaction data too short")
+	}
+
+	functionSelector := hex.EncodeToString(tx.Data()[:4])
+	if functionSelector != "a9059cbb" {
+		return burnTransfer{}, skipf("not a transfer function call: %s", functionSelector)
+	}
+
+	if tx.To() == nil {
+		return burnTransfer{}, skipf("contract creation is not a transfer")
+	}
+
+	// Decode transfer function data
+	var to common.Address
+	var value *big.Int
+
+	err := d.contractABI.UnpackIntoInterface(&[]interface{}{&to, &value}, "transfer", tx.Data()[4:])
+	if err != nil {
+		return burnTransfer{}, skipf("failed to decode transfer data: %v", err)
+	}
+
+	// Check if tokens are being sent to dead address
+	if !d.isDeadAddress(to) {
+		return burnTransfer{}, skipf("tokens not sent to dead address: %s", to.Hex())
+	}
+
+	return burnTransfer{LP: *tx.To(), Value: value}, nil
+}
+
+// isDeadAddress compares addresses by value so a checksummed or otherwise
+// non-lowercase DeadAddr still matches.
+func (d *LPBurnDetector) isDeadAddress(addr common.Address) bool {
+	return addr == common.HexToAddress(d.config.DeadAddr)
+}
+
+// aggregateTransfers sums the transfers per LP token, keeping the order in
+// which each LP first appeared. When one tx shows up as several kinds of
+// event for the same LP (e.g. a dead-address transfer and a pair Burn) only
+// the kind that comes first in lpEventKind order is kept, so a single
+// liquidity removal alerts once.
+func aggregateTransfers(transfers []burnTransfer) []burnTransfer {
+	var aggregated []burnTransfer
+	index := make(map[common.Address]int)
+	for _, t := range transfers {
+		i, ok := index[t.LP]
+		switch {
+		case !ok:
+			index[t.LP] = len(aggregated)
+			aggregated = append(aggregated, burnTransfer{LP: t.LP, Value: new(big.Int).Set(t.Value), Kind: t.Kind})
+		case t.Kind == aggregated[i].Kind:
+			aggregated[i].Value = new(big.Int).Add(aggregated[i].Value, t.Value)
+		case t.Kind < aggregated[i].Kind:
+			aggregated[i] = burnTransfer{LP: t.LP, Value: new(big.Int).Set(t.Value), Kind: t.Kind}
+		}
+	}
+	return aggregated
+}
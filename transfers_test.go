@@ -0,0 +1,45 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestIsDeadAddressChecksummed(t *testing.T) {
+	for _, configured := range []string{
+		"0x000000000000000000000000000000000000dEaD",
+		"0x000000000000000000000000000000000000DEAD",
+		"0x000000000000000000000000000000000000dead",
+	} {
+		t.Run(configured, func(t *testing.T) {
+			t.Setenv("DEAD_ADDR", configured)
+			cfg, err := LoadConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := &LPBurnDetector{config: cfg}
+
+			dead := common.HexToAddress("0x000000000000000000000000000000000000dead")
+			if !d.isDeadAddress(dead) {
+				t.Errorf("isDeadAddress(%s) = false with DEAD_ADDR=%s", dead.Hex(), configured)
+			}
+			if d.isDeadAddress(common.Address{}) {
+				t.Errorf("isDeadAddress(zero address) = true with DEAD_ADDR=%s", configured)
+			}
+
+			// A Transfer log to the dead address decodes as a burn
+			lp := common.HexToAddress("0x2222222222222222222222222222222222222222")
+			vLog := types.Log{
+				Address: lp,
+				Topics:  []common.Hash{transferEventTopic, addressTopic(lp), addressTopic(dead)},
+				Data:    common.LeftPadBytes(big.NewInt(1000).Bytes(), 32),
+			}
+			if transfers := d.transfersFromLogs([]types.Log{vLog}); len(transfers) != 1 {
+				t.Errorf("got %d transfers, want 1", len(transfers))
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// WebhookNotifier posts every alert as JSON to a generic HTTP endpoint:
+//
+//	{"text": "<plain text>", "html": "<Telegram HTML>", "mcap": 123, "thread": "0x…", "sent_at": "RFC 3339"}
+//
+// With a secret configured each request is signed. X-Timestamp carries the
+// Unix time in seconds and X-Signature is "sha256=" followed by the hex
+// HMAC-SHA256, keyed with the secret, of the timestamp, a ".", and the raw
+// request body exactly as received (no re-encoding). Consumers should
+// recompute it, compare in constant time and reject stale timestamps (say,
+// over 5 minutes old) to stop replays.
+type WebhookNotifier struct {
+	httpClient  *http.Client
+	url         string
+	secret      []byte
+	timeout     time.Duration
+	maxAttempts int
+	baseDelay   time.Duration
+	breaker     *CircuitBreaker
+	queue       *alertQueue
+	clock       Clock
+}
+
+type webhookPayload struct {
+	Text   string    `json:"text"`
+	HTML   string    `json:"html"`
+	Mcap   int64     `json:"mcap"`
+	Thread string    `json:"thread,omitempty"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+func NewWebhookNotifier(httpClient *http.Client, url, secret string, timeout time.Duration, maxAttempts int, baseDelay time.Duration, breaker *CircuitBreaker, queueSize int, clock Clock) *WebhookNotifier {
+	return &WebhookNotifier{
+		httpClient:  httpClient,
+		url:         url,
+		secret:      []byte(secret),
+		timeout:     timeout,
+		maxAttempts: maxAttempts,
+		baseDelay:   baseDelay,
+		breaker:     breaker,
+		queue:       newAlertQueue(queueSize),
+		clock:       clock,
+	}
+}
+
+func (w *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+func (w *WebhookNotifier) Notify(alert Alert) error {
+	if dropped := w.queue.push(alert); dropped != nil {
+		log.Printf("⚠️ Webhook queue full, dropped alert with mcap $%s", formatNumber(dropped.Mcap))
+	}
+	return nil
+}
+
+// run drains the alert queue until ctx is cancelled.
+func (w *WebhookNotifier) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.queue.ready:
+		}
+
+		for {
+			alert, ok := w.queue.pop()
+			if !ok {
+				break
+			}
+
+			data, err := json.Marshal(webhookPayload{
+				Text:   stripHTML(alert.Text),
+				HTML:   alert.Text,
+				Mcap:   alert.Mcap,
+				Thread: alert.Thread,
+				SentAt: w.clock.Now().UTC(),
+			})
+			if err != nil {
+				log.Printf("❌ Failed to encode webhook payload: %v", err)
+				continue
+			}
+
+			postCtx, cancel := context.WithTimeout(ctx, w.timeout)
+			err = callProvider(postCtx, w.breaker, w.clock, "Webhook", w.maxAttempts, w.baseDelay, func() error {
+				return w.post(postCtx, data)
+			})
+			cancel()
+			if err != nil {
+				log.Printf("❌ Failed to send webhook: %v", err)
+			}
+		}
+	}
+}
+
+// post sends data, signing it afresh so a retry carries a current
+// timestamp.
+func (w *WebhookNotifier) post(ctx context.Context, data []byte) error {
+	headers := map[string]string{"Content-Type": "application/json"}
+	if len(w.secret) > 0 {
+		timestamp := strconv.FormatInt(w.clock.Now().Unix(), 10)
+		headers["X-Timestamp"] = timestamp
+		headers["X-Signature"] = "sha256=" + signWebhook(w.secret, timestamp, data)
+	}
+
+	_, err := httpDo(ctx, w.httpClient, "POST", w.url, headers, data)
+	return err
+}
+
+// signWebhook returns the hex HMAC-SHA256 of timestamp + "." + body.
+func signWebhook(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
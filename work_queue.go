@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// workQueue sits between the subscription loop and the workers processing
+// tx batches and liquidity adds. It's bounded so a slow pipeline drops jobs,
+// counted in dropped, instead of blocking the subscription receive loop.
+type workQueue struct {
+	jobs    chan func()
+	dropped atomic.Int64
+}
+
+func newWorkQueue(size int) *workQueue {
+	return &workQueue{jobs: make(chan func(), size)}
+}
+
+// offer queues job without blocking and reports whether it fit.
+func (q *workQueue) offer(job func()) bool {
+	select {
+	case q.jobs <- job:
+		return true
+	default:
+		q.dropped.Add(1)
+		return false
+	}
+}
+
+func (q *workQueue) depth() int {
+	return len(q.jobs)
+}
+
+// runWorkers starts WorkerCount workers running queued jobs until ctx is
+// cancelled.
+func (d *LPBurnDetector) runWorkers(ctx context.Context) {
+	for i := 0; i < d.config.WorkerCount; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-d.work.jobs:
+					job()
+				}
+			}
+		}()
+	}
+}
+
+// enqueueBatch hands batch to the workers, logging when the queue is full
+// and it has to be dropped.
+func (d *LPBurnDetector) enqueueBatch(batch []types.Log) {
+	if !d.work.offer(func() { d.processBatch(batch) }) {
+		log.Printf("⚠️ Work queue full (%d), dropping tx %s", cap(d.work.jobs), batch[0].TxHash.Hex())
+	}
+}
+
+// enqueueLiquidityAdd hands a Mint log to the workers, the same way as
+// enqueueBatch.
+func (d *LPBurnDetector) enqueueLiquidityAdd(vLog types.Log) {
+	if !d.work.offer(func() { d.handleLiquidityAdd(vLog) }) {
+		log.Printf("⚠️ Work queue full (%d), dropping liquidity add in tx %s", cap(d.work.jobs), vLog.TxHash.Hex())
+	}
+}
@@ -0,0 +1,16 @@
+package main
+
+import "testing"
+
+func TestWorkQueueDropsWhenFull(t *testing.T) {
+	q := newWorkQueue(2)
+	for i := 0; i < 3; i++ {
+		q.offer(func() {})
+	}
+	if got := q.depth(); got != 2 {
+		t.Errorf("depth = %d, want 2", got)
+	}
+	if got := q.dropped.Load(); got != 1 {
+		t.Errorf("dropped = %d, want 1", got)
+	}
+}